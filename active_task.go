@@ -0,0 +1,101 @@
+package couchdb
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+// TaskMatcher defines a function that selects active tasks.
+type TaskMatcher func(value.ActiveTask) bool
+
+// MatchIndexer returns a matcher for the indexer tasks of the provided design document.
+func MatchIndexer(database, designDocument string) TaskMatcher {
+	return matchTask(value.ActiveTaskIndexer, database, designDocument)
+}
+
+// MatchViewCompaction returns a matcher for the view compaction tasks of the provided design document.
+func MatchViewCompaction(database, designDocument string) TaskMatcher {
+	return matchTask(value.ActiveTaskViewCompaction, database, designDocument)
+}
+
+// MatchDatabaseCompaction returns a matcher for the compaction tasks of the provided database.
+func MatchDatabaseCompaction(database string) TaskMatcher {
+	return matchTask(value.ActiveTaskDatabaseCompaction, database, "")
+}
+
+// ActiveTasks fetches the tasks, that are currently running on the nodes of the cluster.
+func (c *Client) ActiveTasks(ctx context.Context) ([]value.ActiveTask, error) {
+	r := []value.ActiveTask{}
+
+	if err := c.requestJSON(ctx, http.MethodGet, "/_active_tasks", nil, nil, &r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// WaitForTask polls the active tasks in the provided interval and blocks until a task matched by the provided
+// matcher has been seen running and finished. Since a task might not have been started yet, it waits for the
+// start grace period, that can be set using the `WithStartGrace` option, before it assumes that there's
+// nothing to wait for.
+func (c *Client) WaitForTask(
+	ctx context.Context,
+	interval time.Duration,
+	match TaskMatcher,
+	options ...MaintenanceOption,
+) error {
+	m := maintenanceOf(append([]MaintenanceOption{WithWait(interval)}, options...))
+	return m.waitFor(ctx, c.taskRunning(match))
+}
+
+// taskRunning returns a function that reports whether a task matched by the provided matcher is running.
+func (c *Client) taskRunning(match TaskMatcher) func(context.Context) (bool, error) {
+	return func(ctx context.Context) (bool, error) {
+		tasks, err := c.ActiveTasks(ctx)
+		if err != nil {
+			return false, err
+		}
+		return containsTask(tasks, match), nil
+	}
+}
+
+func matchTask(taskType value.ActiveTaskType, database, designDocument string) TaskMatcher {
+	if designDocument != "" && !strings.HasPrefix(designDocument, designPrefix) {
+		designDocument = designPrefix + designDocument
+	}
+	return func(task value.ActiveTask) bool {
+		return task.Type == taskType &&
+			taskDatabase(task.Database) == database &&
+			(designDocument == "" || task.DesignDocument == designDocument)
+	}
+}
+
+// taskDatabase returns the name of the database of the provided shard name, e.g.
+// `shards/00000000-7fffffff/test.1615906832`.
+func taskDatabase(name string) string {
+	if !strings.HasPrefix(name, "shards/") {
+		return name
+	}
+	parts := strings.SplitN(name, "/", 3)
+	if len(parts) < 3 {
+		return name
+	}
+	name = parts[2]
+	if index := strings.LastIndex(name, "."); index >= 0 {
+		name = name[:index]
+	}
+	return name
+}
+
+func containsTask(tasks []value.ActiveTask, match TaskMatcher) bool {
+	for _, task := range tasks {
+		if match(task) {
+			return true
+		}
+	}
+	return false
+}
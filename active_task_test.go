@@ -0,0 +1,103 @@
+package couchdb_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+	"github.com/simia-tech/couchdb/value"
+)
+
+func TestActiveTasks(t *testing.T) {
+	e := setUpTestEnvironment(t)
+	defer e.tearDown()
+
+	_, err := e.client.ActiveTasks(e.ctx)
+	require.NoError(t, err)
+}
+
+func TestWaitForTask(t *testing.T) {
+	responses := []string{
+		`[{"type":"indexer","node":"node1","pid":"<0.1.0>","database":"shards/00000000-7fffffff/test.1615906832",` +
+			`"design_document":"_design/test","progress":50,"changes_done":5,"total_changes":10}]`,
+		`[{"type":"indexer","node":"node1","pid":"<0.1.0>","database":"shards/00000000-7fffffff/test.1615906832",` +
+			`"design_document":"_design/test","progress":90,"changes_done":9,"total_changes":10},` +
+			`{"type":"database_compaction","node":"node1","pid":"<0.2.0>","database":"shards/00000000-7fffffff/test.1615906832"}]`,
+		`[{"type":"database_compaction","node":"node1","pid":"<0.2.0>","database":"shards/00000000-7fffffff/test.1615906832"}]`,
+	}
+
+	mutex := sync.Mutex{}
+	calls := 0
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/_active_tasks", func(stubRequest) stubResponse {
+		mutex.Lock()
+		defer mutex.Unlock()
+		response := responses[calls]
+		if calls < len(responses)-1 {
+			calls++
+		}
+		return stubResponse{statusCode: 200, body: response}
+	})
+
+	t.Run("ActiveTasks", func(t *testing.T) {
+		tasks, err := client.ActiveTasks(context.Background())
+		require.NoError(t, err)
+
+		require.Len(t, tasks, 1)
+		assert.Equal(t, value.ActiveTaskIndexer, tasks[0].Type)
+		assert.Equal(t, uint(50), tasks[0].Progress)
+		assert.Equal(t, uint(10), tasks[0].TotalChanges)
+	})
+
+	t.Run("Indexer", func(t *testing.T) {
+		require.NoError(t, client.WaitForTask(context.Background(), time.Millisecond, couchdb.MatchIndexer("test", "test")))
+
+		mutex.Lock()
+		assert.Equal(t, len(responses)-1, calls)
+		mutex.Unlock()
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := client.WaitForTask(ctx, time.Millisecond, couchdb.MatchDatabaseCompaction("test"))
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestWaitForTaskStart(t *testing.T) {
+	mutex := sync.Mutex{}
+	calls := 0
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/_active_tasks", func(stubRequest) stubResponse {
+		mutex.Lock()
+		defer mutex.Unlock()
+		calls++
+		if calls == 3 {
+			return stubResponse{statusCode: 200, body: `[{"type":"indexer","database":"shards/00000000-7fffffff/test.1","design_document":"_design/test"}]`}
+		}
+		return stubResponse{statusCode: 200, body: `[]`}
+	})
+
+	t.Run("Started", func(t *testing.T) {
+		require.NoError(t, client.WaitForTask(context.Background(), time.Millisecond, couchdb.MatchIndexer("test", "test")))
+
+		mutex.Lock()
+		assert.Equal(t, 4, calls)
+		mutex.Unlock()
+	})
+
+	t.Run("NeverStarted", func(t *testing.T) {
+		start := time.Now()
+		require.NoError(t, client.WaitForTask(context.Background(), time.Millisecond, couchdb.MatchIndexer("test", "test"),
+			couchdb.WithStartGrace(20*time.Millisecond)))
+
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	})
+}
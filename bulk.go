@@ -0,0 +1,63 @@
+package couchdb
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+// BulkOption defines a function that can modify a bulk request.
+type BulkOption func(*bulkRequest)
+
+type bulkRequest struct {
+	Docs     []interface{} `json:"docs"`
+	NewEdits *bool         `json:"new_edits,omitempty"`
+}
+
+// WithNewEdits returns an option that sets whether new revisions are assigned to the documents. If set to
+// false, the documents are stored with their provided revisions, like it's required for replication.
+func WithNewEdits(value bool) BulkOption {
+	return func(r *bulkRequest) {
+		r.NewEdits = &value
+	}
+}
+
+// BulkDocs creates, updates and deletes the provided documents in a single request. The result holds one
+// entry per document, which has to be checked for errors.
+func (db *Database) BulkDocs(ctx context.Context, docs []interface{}, options ...BulkOption) ([]value.BulkResult, error) {
+	request := &bulkRequest{Docs: docs}
+	for _, o := range options {
+		o(request)
+	}
+
+	r := []value.BulkResult{}
+	if err := db.requestJSON(ctx, http.MethodPost, db.path("_bulk_docs"), nil, request, &r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// bulkResultError returns the error of the provided bulk result, which is mapped by its kind. A successful
+// result returns nil.
+func bulkResultError(result value.BulkResult) error {
+	err := error(nil)
+	switch result.Error {
+	case "":
+		return nil
+	case "bad_request":
+		err = ErrBadRequest
+	case "unauthorized":
+		err = ErrUnauthorized
+	case "forbidden":
+		err = ErrForbidden
+	case "not_found":
+		err = ErrNotFound
+	case "conflict":
+		err = ErrConflict
+	default:
+		return fmt.Errorf("%s: %s", result.Error, result.Reason)
+	}
+	return fmt.Errorf("%w: %s", err, result.Reason)
+}
@@ -0,0 +1,32 @@
+package couchdb
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+// BulkGet fetches the provided document revisions in a single request. The fetch options like
+// `WithRevisions` and `WithAttachments` apply to all documents.
+func (db *Database) BulkGet(
+	ctx context.Context,
+	requests []value.BulkGetRequest,
+	options ...FetchOption,
+) ([]value.BulkGetResult, error) {
+	query, err := fetchQuery(options)
+	if err != nil {
+		return nil, err
+	}
+
+	body := struct {
+		Docs []value.BulkGetRequest `json:"docs"`
+	}{Docs: requests}
+	r := struct {
+		Results []value.BulkGetResult `json:"results"`
+	}{}
+	if err := db.requestJSON(ctx, http.MethodPost, withQuery(db.path("_bulk_get"), query), nil, body, &r); err != nil {
+		return nil, err
+	}
+	return r.Results, nil
+}
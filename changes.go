@@ -0,0 +1,53 @@
+package couchdb
+
+import (
+	"context"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+// ChangesFeed implements an iterator over the entries of a changes feed.
+type ChangesFeed struct {
+	feed *feed[value.Change]
+}
+
+// Changes requests the changes feed of the database. The returned feed has to be closed by the caller.
+// Continuous feeds are streamed until the context is cancelled, the timeout is reached or the feed is closed.
+func (db *Database) Changes(ctx context.Context, options ...FeedOption) (*ChangesFeed, error) {
+	f, err := openFeed[value.Change](ctx, db.client.codec, db.requestJSON, db.path("_changes"), options)
+	if err != nil {
+		return nil, err
+	}
+	return &ChangesFeed{feed: f}, nil
+}
+
+// Next advances to the next change and returns false if there are no more changes or an error occurred.
+func (cf *ChangesFeed) Next() bool {
+	return cf.feed.next()
+}
+
+// Change returns the current change.
+func (cf *ChangesFeed) Change() value.Change {
+	return cf.feed.current
+}
+
+// LastSeq returns the last sequence of the feed. It's available after `Next` returned false.
+func (cf *ChangesFeed) LastSeq() value.Seq {
+	return cf.feed.lastSeq
+}
+
+// Pending returns the number of changes that haven't been returned due to the limit. It's available
+// after `Next` returned false.
+func (cf *ChangesFeed) Pending() uint64 {
+	return cf.feed.pending
+}
+
+// Err returns the error that occurred while reading the feed.
+func (cf *ChangesFeed) Err() error {
+	return cf.feed.err
+}
+
+// Close closes the feed.
+func (cf *ChangesFeed) Close() error {
+	return cf.feed.close()
+}
@@ -0,0 +1,60 @@
+package couchdb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+)
+
+func TestChanges(t *testing.T) {
+	e := setUpTestEnvironment(t)
+	defer e.tearDown()
+
+	db := couchdb.NewDatabase(e.client, "test")
+	require.NoError(t, db.Create(e.ctx))
+	defer db.Delete(e.ctx)
+
+	require.NoError(t, couchdb.NewDocument(db, "one", "").Store(e.ctx, map[string]interface{}{"test": "one"}))
+	require.NoError(t, couchdb.NewDocument(db, "two", "").Store(e.ctx, map[string]interface{}{"test": "two"}))
+
+	t.Run("Normal", func(t *testing.T) {
+		feed, err := db.Changes(e.ctx)
+		require.NoError(t, err)
+		defer feed.Close()
+
+		ids := []string{}
+		for feed.Next() {
+			ids = append(ids, feed.Change().ID)
+		}
+		require.NoError(t, feed.Err())
+
+		assert.ElementsMatch(t, []string{"one", "two"}, ids)
+		assert.NotEmpty(t, feed.LastSeq())
+	})
+
+	t.Run("Continuous", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(e.ctx, 5*time.Second)
+		defer cancel()
+
+		feed, err := db.Changes(ctx,
+			couchdb.WithFeed(couchdb.FeedContinuous),
+			couchdb.WithFeedIncludeDocs(),
+			couchdb.WithHeartbeat(time.Second))
+		require.NoError(t, err)
+		defer feed.Close()
+
+		require.True(t, feed.Next())
+		require.True(t, feed.Next())
+
+		require.NoError(t, couchdb.NewDocument(db, "three", "").Store(e.ctx, map[string]interface{}{"test": "three"}))
+
+		require.True(t, feed.Next())
+		assert.Equal(t, "three", feed.Change().ID)
+		assert.NotEmpty(t, feed.Change().Doc)
+	})
+}
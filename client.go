@@ -9,39 +9,67 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/simia-tech/couchdb/value"
 )
 
 // Various errors.
 var (
-	ErrBadRequest = errors.New("bad request")
-	ErrConflict   = errors.New("conflict")
+	ErrBadRequest         = errors.New("bad request")
+	ErrUnauthorized       = errors.New("unauthorized")
+	ErrForbidden          = errors.New("forbidden")
+	ErrNotFound           = errors.New("not found")
+	ErrConflict           = errors.New("conflict")
+	ErrPreconditionFailed = errors.New("precondition failed")
+	ErrUnexpectedStatus   = errors.New("unexpected status")
 )
 
+// dbsInfoBatchSize matches the default of `max_db_number_for_dbs_info_req`.
+const dbsInfoBatchSize = 100
+
 // Client implements a simple couch db client.
 type Client struct {
-	baseURL  string
-	username string
-	password string
+	baseURLs          []string
+	username          string
+	password          string
+	endpointSelection EndpointSelection
+	failureThreshold  int
+	probeInterval     time.Duration
 
 	httpClient HTTPClient
+	codec      Codec
+	endpoints  *endpointPool
+	observers  []Observer
 }
 
-// NewClient returns a new client configured with the provided options.
+// NewClient returns a new client configured with the provided options. Further node urls
+// can be added using the `WithEndpoints` option.
 func NewClient(baseURL string, options ...ClientOption) (*Client, error) {
 	c := &Client{
-		baseURL:    baseURL,
-		httpClient: NewHTTPClientStd(nil),
+		baseURLs:          []string{baseURL},
+		endpointSelection: RoundRobin,
+		failureThreshold:  defaultFailureThreshold,
+		probeInterval:     defaultProbeInterval,
+		httpClient:        NewHTTPClientStd(nil),
+		codec:             JSONCodec{},
 	}
 	for _, o := range options {
 		if err := o(c); err != nil {
 			return nil, err
 		}
 	}
+	c.endpoints = newEndpointPool(c.baseURLs, c.endpointSelection, c.failureThreshold, c.probeInterval, c.probe)
 	return c, nil
 }
 
+// Close stops the background probing of unhealthy endpoints.
+func (c *Client) Close() error {
+	c.endpoints.close()
+	return nil
+}
+
 // InstanceInfo fetches some basic infos from the couchdb instance.
 func (c *Client) InstanceInfo(ctx context.Context) (value.InstanceInfo, error) {
 	r := value.InstanceInfo{}
@@ -53,17 +81,66 @@ func (c *Client) InstanceInfo(ctx context.Context) (value.InstanceInfo, error) {
 	return r, nil
 }
 
-// AllDatabases fetches a list of all databases.
-func (c *Client) AllDatabases(ctx context.Context) ([]string, error) {
+// requireFeature returns `ErrUnsupportedFeature` if the instance doesn't report the provided feature.
+func (c *Client) requireFeature(ctx context.Context, feature string) error {
+	ii, err := c.InstanceInfo(ctx)
+	if err != nil {
+		return err
+	}
+	for _, f := range ii.Features {
+		if f == feature {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrUnsupportedFeature, feature)
+}
+
+// AllDatabases fetches a list of all databases. The list can be narrowed and paged using the `WithStartKey`,
+// `WithEndKey`, `WithLimit`, `WithSkip` and `WithDescending` options.
+func (c *Client) AllDatabases(ctx context.Context, options ...ViewOption) ([]string, error) {
 	r := []string{}
 
-	if err := c.requestJSON(ctx, http.MethodGet, "/_all_dbs", nil, nil, &r); err != nil {
+	query, err := viewQuery(options)
+	if err != nil {
+		return r, err
+	}
+
+	if err := c.requestJSON(ctx, http.MethodGet, withQuery("/_all_dbs", query), nil, nil, &r); err != nil {
 		return r, err
 	}
 
 	return r, nil
 }
 
+// DatabasesInfo fetches infos about the databases with the provided names. The names are requested in
+// batches, that stay within the server's default limit of keys per request. The results are returned in the
+// order of the names.
+func (c *Client) DatabasesInfo(ctx context.Context, names []string) ([]value.DatabaseInfoResult, error) {
+	results := []value.DatabaseInfoResult{}
+
+	for start := 0; start < len(names); start += dbsInfoBatchSize {
+		end := start + dbsInfoBatchSize
+		if end > len(names) {
+			end = len(names)
+		}
+
+		r := []value.DatabaseInfoResult{}
+		body := struct {
+			Keys []string `json:"keys"`
+		}{Keys: names[start:end]}
+		if err := c.requestJSON(ctx, http.MethodPost, "/_dbs_info", nil, body, &r); err != nil {
+			return results, err
+		}
+		results = append(results, r...)
+	}
+
+	return results, nil
+}
+
+// requestJSON performs a request with a json body and decodes the json response into the provided
+// response body. A `json.RawMessage` or an `io.Reader` body is sent as it is. If the response body is
+// a `*json.RawMessage`, it receives the raw response, if it's an `*io.ReadCloser`, it receives the response
+// stream, which has to be closed by the caller.
 func (c *Client) requestJSON(
 	ctx context.Context,
 	method,
@@ -72,41 +149,81 @@ func (c *Client) requestJSON(
 	body,
 	responseBody interface{},
 ) error {
+	_, err := c.requestJSONWithHeader(ctx, method, path, header, body, responseBody)
+	return err
+}
+
+// requestJSONWithHeader works like `requestJSON` and also returns the response header.
+func (c *Client) requestJSONWithHeader(
+	ctx context.Context,
+	method,
+	path string,
+	header http.Header,
+	body,
+	responseBody interface{},
+) (http.Header, error) {
 	if header == nil {
 		header = http.Header{}
 	}
 	header.Add("Accept", "application/json")
 
 	bodyReader := io.Reader(nil)
-	if body != nil {
+	switch b := body.(type) {
+	case nil:
+	case json.RawMessage:
+		bodyReader = bytes.NewReader(b)
+	case io.Reader:
+		bodyReader = b
+	default:
 		buffer := &bytes.Buffer{}
-		if err := json.NewEncoder(buffer).Encode(body); err != nil {
-			return fmt.Errorf("json encode: %w", err)
+		if err := c.codec.Encode(buffer, body); err != nil {
+			return nil, fmt.Errorf("encode: %w", err)
 		}
-		bodyReader = buffer
-		header.Add("Content-Type", "application/json")
+		bodyReader = bytes.NewReader(buffer.Bytes())
+	}
+	if bodyReader != nil && header.Get("Content-Type") == "" {
+		header.Set("Content-Type", "application/json")
 	}
 
-	statusCode, _, responseReader, err := c.request(ctx, method, path, header, bodyReader)
+	statusCode, responseHeader, responseReader, err := c.request(ctx, method, path, header, bodyReader)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer responseReader.Close()
 
-	reader := io.Reader(responseReader)
-	// b := bytes.Buffer{}
-	// reader = io.TeeReader(reader, &b)
+	return responseHeader, c.decodeJSON(statusCode, responseReader, responseBody)
+}
 
-	if err := checkJSONError(statusCode, reader); err != nil {
+// decodeJSON checks the status code and decodes the response into the provided response body. Except for
+// an `*io.ReadCloser` response body, the response reader gets closed.
+func (c *Client) decodeJSON(statusCode int, responseReader io.ReadCloser, responseBody interface{}) error {
+	if err := checkJSONError(statusCode, responseReader); err != nil {
+		responseReader.Close()
 		return err
 	}
 
-	if err := json.NewDecoder(reader).Decode(responseBody); err != nil {
-		return fmt.Errorf("json decode: %w", err)
+	switch rb := responseBody.(type) {
+	case *io.ReadCloser:
+		*rb = responseReader
+		return nil
+	case *json.RawMessage:
+		defer responseReader.Close()
+		data, err := io.ReadAll(responseReader)
+		if err != nil {
+			return fmt.Errorf("read: %w", err)
+		}
+		*rb = data
+	case nil:
+		defer responseReader.Close()
+		if _, err := io.Copy(io.Discard, responseReader); err != nil {
+			return fmt.Errorf("read: %w", err)
+		}
+	default:
+		defer responseReader.Close()
+		if err := c.codec.Decode(responseReader, responseBody); err != nil {
+			return fmt.Errorf("decode: %w", err)
+		}
 	}
 
-	// log.Printf("json repsonse:\n%s\n", b.String())
-
 	return nil
 }
 
@@ -117,26 +234,131 @@ func (c *Client) request(
 	header http.Header,
 	body io.Reader,
 ) (int, http.Header, io.ReadCloser, error) {
-	url := c.baseURL + path
+	if header == nil {
+		header = http.Header{}
+	}
+	c.authorize(header)
+
+	// The body gets wrapped to count the sent bytes, which hides its length from the http client.
+	if sized, ok := body.(interface{ Len() int }); ok && header.Get("Content-Length") == "" {
+		header.Set("Content-Length", strconv.Itoa(sized.Len()))
+	}
+
+	ri := newRequestInfo(method, path)
+	ctx = c.observeStart(ctx, ri)
+	result := ResponseInfo{}
+	start := time.Now()
+
+	// Only requests without a body or with a rewindable body can be sent to another endpoint. Requests that
+	// are not idempotent are only sent to another endpoint, if they provably never reached the first one.
+	seeker, replayable := body.(io.Seeker)
+	replayable = replayable || body == nil
+	idempotent := isIdempotent(method)
+
+	candidates := c.endpoints.candidates()
+	for index, e := range candidates {
+		if index > 0 {
+			if !replayable {
+				break
+			}
+			if seeker != nil {
+				if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+					result.Err = fmt.Errorf("seek: %w", err)
+					break
+				}
+			}
+		}
+		last := index == len(candidates)-1 || !replayable
+
+		counter, requestBody := (*countingReader)(nil), io.Reader(nil)
+		if body != nil {
+			counter = &countingReader{reader: body}
+			requestBody = counter
+		}
+
+		attemptStart := time.Now()
+		statusCode, responseHeader, responseReader, err := c.httpClient.Request(ctx, method, e.baseURL+path, header, requestBody)
+		result.Endpoint = e.baseURL
+		result.BytesSent = counter.count()
+		if err != nil {
+			result.Err = err
+			if ctx.Err() != nil {
+				break
+			}
+			c.endpoints.failed(e)
+			if !idempotent && !isDialError(err) {
+				break
+			}
+			continue
+		}
+		if isUnavailable(statusCode) {
+			c.endpoints.failed(e)
+			if !last && idempotent {
+				responseReader.Close()
+				result.Err = fmt.Errorf("endpoint %s: status %d", e.baseURL, statusCode)
+				continue
+			}
+		} else {
+			c.endpoints.succeeded(e, time.Since(attemptStart))
+		}
+
+		result.StatusCode, result.Err = statusCode, nil
+		return statusCode, responseHeader, c.observeFinish(ctx, ri, result, start, responseReader), nil
+	}
+
+	c.observeFinish(ctx, ri, result, start, nil)
+	return 0, nil, nil, result.Err
+}
 
+func (c *Client) authorize(header http.Header) {
 	if c.username != "" && c.password != "" {
-		header.Add("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(c.username+":"+c.password)))
+		header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(c.username+":"+c.password)))
 	}
+}
+
+// probe checks if the provided endpoint is up again.
+func (c *Client) probe(ctx context.Context, e *endpoint) bool {
+	header := http.Header{}
+	c.authorize(header)
 
-	statusCode, responseHeader, responseReader, err := c.httpClient.Request(ctx, method, url, header, body)
+	statusCode, _, responseReader, err := c.httpClient.Request(ctx, http.MethodGet, e.baseURL+"/_up", header, nil)
 	if err != nil {
-		return 0, nil, nil, err
+		return false
 	}
+	responseReader.Close()
 
-	return statusCode, responseHeader, responseReader, nil
+	return statusCode == http.StatusOK
 }
 
+// checkJSONError returns an error for every status code from 400 on. The error and the reason of the
+// response body are added, if present.
 func checkJSONError(statusCode int, reader io.Reader) error {
+	if statusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	err := statusError(statusCode)
+	status := value.Status{}
+	if reader != nil && json.NewDecoder(reader).Decode(&status) == nil && status.Error != "" {
+		return fmt.Errorf("%w: %s: %s", err, status.Error, status.Reason)
+	}
+	return err
+}
+
+func statusError(statusCode int) error {
 	switch statusCode {
 	case http.StatusBadRequest:
 		return ErrBadRequest
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
 	case http.StatusConflict:
 		return ErrConflict
+	case http.StatusPreconditionFailed:
+		return ErrPreconditionFailed
 	}
-	return nil
+	return fmt.Errorf("%w %d", ErrUnexpectedStatus, statusCode)
 }
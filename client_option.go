@@ -1,5 +1,10 @@
 package couchdb
 
+import (
+	"fmt"
+	"time"
+)
+
 // ClientOption defines a function the can modify the client parameters.
 type ClientOption func(*Client) error
 
@@ -18,3 +23,69 @@ func WithPassword(value string) ClientOption {
 		return nil
 	}
 }
+
+// WithEndpoints returns an option that adds further node urls to the client. Requests are spread
+// over all healthy endpoints and fail over to the next one if an endpoint can't be reached.
+func WithEndpoints(baseURLs ...string) ClientOption {
+	return func(c *Client) error {
+		c.baseURLs = append(c.baseURLs, baseURLs...)
+		return nil
+	}
+}
+
+// WithEndpointSelection returns an option that sets the strategy to pick an endpoint for a request.
+func WithEndpointSelection(value EndpointSelection) ClientOption {
+	return func(c *Client) error {
+		c.endpointSelection = value
+		return nil
+	}
+}
+
+// WithFailureThreshold returns an option that sets the number of consecutive failures after which
+// an endpoint is marked unhealthy.
+func WithFailureThreshold(value int) ClientOption {
+	return func(c *Client) error {
+		if value < 1 {
+			return fmt.Errorf("invalid failure threshold %d", value)
+		}
+		c.failureThreshold = value
+		return nil
+	}
+}
+
+// WithProbeInterval returns an option that sets the interval in which unhealthy endpoints are probed.
+func WithProbeInterval(value time.Duration) ClientOption {
+	return func(c *Client) error {
+		if value <= 0 {
+			return fmt.Errorf("invalid probe interval %s", value)
+		}
+		c.probeInterval = value
+		return nil
+	}
+}
+
+// WithHTTPClient returns an option that sets the http client that is used to perform the requests.
+func WithHTTPClient(value HTTPClient) ClientOption {
+	return func(c *Client) error {
+		c.httpClient = value
+		return nil
+	}
+}
+
+// WithObserver returns an option that adds an observer to the client. Observers are notified in the
+// order they have been added.
+func WithObserver(value Observer) ClientOption {
+	return func(c *Client) error {
+		c.observers = append(c.observers, value)
+		return nil
+	}
+}
+
+// WithCodec returns an option that sets the codec that is used to encode the request bodies and
+// decode the response bodies. It defaults to `JSONCodec`.
+func WithCodec(value Codec) ClientOption {
+	return func(c *Client) error {
+		c.codec = value
+		return nil
+	}
+}
@@ -1,10 +1,16 @@
 package couchdb_test
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
 )
 
 func TestClientInfo(t *testing.T) {
@@ -29,4 +35,68 @@ func TestClientInfo(t *testing.T) {
 
 		assert.Equal(t, []string{}, dbs)
 	})
+
+	t.Run("AllDatabasesWithOptions", func(t *testing.T) {
+		for _, name := range []string{"test-a", "test-b", "test-c"} {
+			db := couchdb.NewDatabase(e.client, name)
+			require.NoError(t, db.Create(e.ctx))
+			defer db.Delete(e.ctx)
+		}
+
+		dbs, err := e.client.AllDatabases(e.ctx, couchdb.WithStartKey("test-b"), couchdb.WithLimit(1))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"test-b"}, dbs)
+	})
+
+	t.Run("DatabasesInfo", func(t *testing.T) {
+		db := couchdb.NewDatabase(e.client, "test")
+		require.NoError(t, db.Create(e.ctx))
+		defer db.Delete(e.ctx)
+
+		results, err := e.client.DatabasesInfo(e.ctx, []string{"test", "missing"})
+		require.NoError(t, err)
+
+		require.Len(t, results, 2)
+		assert.Equal(t, "test", results[0].Key)
+		require.NotNil(t, results[0].Info)
+		assert.Equal(t, "test", results[0].Info.Name)
+		assert.Equal(t, "missing", results[1].Key)
+		assert.Nil(t, results[1].Info)
+		assert.Equal(t, "not_found", results[1].Error)
+	})
+}
+
+func TestDatabasesInfoBatches(t *testing.T) {
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/_dbs_info", func(request stubRequest) stubResponse {
+		body := struct {
+			Keys []string `json:"keys"`
+		}{}
+		if err := json.Unmarshal([]byte(request.body), &body); err != nil {
+			return stubResponse{statusCode: 400, body: `{"error":"bad_request"}`}
+		}
+		if len(body.Keys) > 100 {
+			return stubResponse{statusCode: 400, body: `{"error":"bad_request","reason":"too many keys"}`}
+		}
+		results := []string{}
+		for _, key := range body.Keys {
+			results = append(results, fmt.Sprintf(`{"key":%q,"info":{"db_name":%q}}`, key, key))
+		}
+		return stubResponse{statusCode: 200, body: "[" + strings.Join(results, ",") + "]"}
+	})
+
+	names := []string{}
+	for index := 0; index < 250; index++ {
+		names = append(names, fmt.Sprintf("tenant-%03d", index))
+	}
+
+	results, err := client.DatabasesInfo(context.Background(), names)
+	require.NoError(t, err)
+
+	require.Len(t, results, len(names))
+	for index, result := range results {
+		assert.Equal(t, names[index], result.Key)
+	}
+	assert.Len(t, httpClient.requests, 3)
 }
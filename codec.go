@@ -0,0 +1,27 @@
+package couchdb
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Codec defines an interface to encode request bodies and decode response bodies.
+type Codec interface {
+	Encode(io.Writer, interface{}) error
+	Decode(io.Reader, interface{}) error
+}
+
+// JSONCodec implements a `Codec` using go's standard library.
+type JSONCodec struct{}
+
+var _ Codec = JSONCodec{}
+
+// Encode writes the json encoding of the provided value to the writer.
+func (JSONCodec) Encode(w io.Writer, value interface{}) error {
+	return json.NewEncoder(w).Encode(value)
+}
+
+// Decode reads the next json value from the reader and stores it in the provided value.
+func (JSONCodec) Decode(r io.Reader, value interface{}) error {
+	return json.NewDecoder(r).Decode(value)
+}
@@ -0,0 +1,152 @@
+package couchdb_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+	"github.com/simia-tech/couchdb/value"
+)
+
+type countingCodec struct {
+	couchdb.JSONCodec
+	encodes int
+	decodes int
+}
+
+func (c *countingCodec) Encode(w io.Writer, value interface{}) error {
+	c.encodes++
+	return c.JSONCodec.Encode(w, value)
+}
+
+func (c *countingCodec) Decode(r io.Reader, value interface{}) error {
+	c.decodes++
+	return c.JSONCodec.Decode(r, value)
+}
+
+func TestCodec(t *testing.T) {
+	ctx := context.Background()
+
+	codec := &countingCodec{}
+	client, httpClient := newStubClient(t, couchdb.WithCodec(codec))
+	httpClient.handle("http://a/test/abc", func(r stubRequest) stubResponse {
+		if r.method == http.MethodGet {
+			return stubResponse{
+				statusCode: 200,
+				header:     http.Header{"Etag": []string{`"1-x"`}},
+				body:       `{"_id":"abc","_rev":"1-x","test":"value"}`,
+			}
+		}
+		return stubResponse{statusCode: 201, body: `{"ok":true,"id":"abc","rev":"1-x"}`}
+	})
+
+	db := couchdb.NewDatabase(client, "test")
+
+	t.Run("Encode", func(t *testing.T) {
+		codec.encodes, codec.decodes = 0, 0
+
+		document := couchdb.NewDocument(db, "abc", "")
+		require.NoError(t, document.Store(ctx, map[string]interface{}{"test": "value"}))
+
+		assert.Equal(t, 1, codec.encodes)
+		assert.Equal(t, 1, codec.decodes)
+		assert.Equal(t, "1-x", document.Revision())
+	})
+
+	t.Run("StoreRawMessage", func(t *testing.T) {
+		codec.encodes, codec.decodes = 0, 0
+		httpClient.reset()
+
+		document := couchdb.NewDocument(db, "abc", "")
+		require.NoError(t, document.Store(ctx, json.RawMessage(`{"test":"raw"}`)))
+
+		assert.Equal(t, 0, codec.encodes)
+		require.Len(t, httpClient.requests, 1)
+		assert.Equal(t, `{"test":"raw"}`, httpClient.requests[0].body)
+		assert.Equal(t, "application/json", httpClient.requests[0].header.Get("Content-Type"))
+	})
+
+	t.Run("StoreReader", func(t *testing.T) {
+		codec.encodes, codec.decodes = 0, 0
+		httpClient.reset()
+
+		document := couchdb.NewDocument(db, "abc", "")
+		require.NoError(t, document.Store(ctx, strings.NewReader(`{"test":"stream"}`)))
+
+		assert.Equal(t, 0, codec.encodes)
+		require.Len(t, httpClient.requests, 1)
+		assert.Equal(t, `{"test":"stream"}`, httpClient.requests[0].body)
+	})
+
+	t.Run("FetchRawMessage", func(t *testing.T) {
+		codec.encodes, codec.decodes = 0, 0
+
+		document := couchdb.NewDocument(db, "abc", "")
+		data := json.RawMessage{}
+		require.NoError(t, document.Fetch(ctx, &data))
+
+		assert.Equal(t, 0, codec.decodes)
+		assert.JSONEq(t, `{"_id":"abc","_rev":"1-x","test":"value"}`, string(data))
+		assert.Equal(t, "1-x", document.Revision())
+	})
+
+	t.Run("FetchReadCloser", func(t *testing.T) {
+		codec.encodes, codec.decodes = 0, 0
+
+		document := couchdb.NewDocument(db, "abc", "")
+		rc := io.ReadCloser(nil)
+		require.NoError(t, document.Fetch(ctx, &rc))
+		defer rc.Close()
+
+		data, err := ioutil.ReadAll(rc)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, codec.decodes)
+		assert.Equal(t, `{"_id":"abc","_rev":"1-x","test":"value"}`, string(data))
+		assert.Equal(t, "1-x", document.Revision())
+	})
+
+	t.Run("ContinuousFeed", func(t *testing.T) {
+		codec.encodes, codec.decodes = 0, 0
+		httpClient.handle("http://a/test/_changes", jsonResponse(200, "{\"seq\":\"1-a\",\"id\":\"abc\"}\n\n"+
+			"{\"last_seq\":\"1-a\",\"pending\":0}\n"))
+
+		feed, err := db.Changes(ctx, couchdb.WithFeed(couchdb.FeedContinuous))
+		require.NoError(t, err)
+		defer feed.Close()
+
+		require.True(t, feed.Next())
+		assert.Equal(t, "abc", feed.Change().ID)
+		assert.False(t, feed.Next())
+		require.NoError(t, feed.Err())
+
+		assert.Equal(t, value.Seq("1-a"), feed.LastSeq())
+		assert.Equal(t, 3, codec.decodes)
+	})
+}
+
+func TestErrorStatusWithRawTargets(t *testing.T) {
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/test/abc", jsonResponse(403, `{"error":"forbidden","reason":"not allowed"}`))
+	httpClient.handle("http://a/test/_changes", jsonResponse(500, `{"error":"unknown_error","reason":"badarg"}`))
+
+	db := couchdb.NewDatabase(client, "test")
+
+	raw := json.RawMessage{}
+	err := couchdb.NewDocument(db, "abc", "").Fetch(context.Background(), &raw)
+	assert.ErrorIs(t, err, couchdb.ErrForbidden)
+	assert.Contains(t, err.Error(), "not allowed")
+	assert.Empty(t, raw)
+
+	_, err = db.Changes(context.Background())
+	assert.ErrorIs(t, err, couchdb.ErrUnexpectedStatus)
+	assert.Contains(t, err.Error(), "500")
+}
@@ -0,0 +1,232 @@
+package couchdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+const conflictsPageSize = 1000
+
+// Resolver defines a function that merges the winning revision and the conflicting revisions of a
+// document into a single document. The documents contain their `_id` and `_rev` fields.
+type Resolver func(winner map[string]interface{}, conflicts []map[string]interface{}) (map[string]interface{}, error)
+
+// ConflictedDocuments returns all documents that have conflicting revisions using a mango query on
+// the `_conflicts` field.
+func (db *Database) ConflictedDocuments(ctx context.Context) ([]value.ConflictedDocument, error) {
+	documents := []value.ConflictedDocument{}
+
+	query := value.FindQuery{
+		Selector:  map[string]interface{}{metaConflicts: map[string]interface{}{"$exists": true}},
+		Fields:    []string{metaID, metaRevision, metaConflicts},
+		Limit:     conflictsPageSize,
+		Conflicts: true,
+	}
+	for {
+		result, err := db.Find(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		for _, doc := range result.Docs {
+			document := value.ConflictedDocument{}
+			if err := json.Unmarshal(doc, &document); err != nil {
+				return nil, fmt.Errorf("json decode: %w", err)
+			}
+			documents = append(documents, document)
+		}
+		if len(result.Docs) < conflictsPageSize {
+			return documents, nil
+		}
+		query.Bookmark = result.Bookmark
+	}
+}
+
+// Resolve loads the winning revision and all conflicting revisions of the document with the provided id and
+// merges them using the resolver. The merged document is stored as a new revision of the winner first. Only
+// if that succeeded, the conflicting revisions are deleted, so a concurrent update of the winner can't cause
+// the loss of the merged data. The new revision is returned, also if the deletion of a conflicting revision
+// failed.
+func (db *Database) Resolve(ctx context.Context, id string, resolver Resolver) (string, error) {
+	winner := map[string]interface{}{}
+	document := NewDocument(db, id, "")
+	if err := document.Fetch(ctx, &winner, WithConflicts()); err != nil {
+		return "", err
+	}
+
+	revisions := stringSlice(winner[metaConflicts])
+	if len(revisions) == 0 {
+		return document.Revision(), nil
+	}
+	delete(winner, metaConflicts)
+
+	conflicts, err := db.openRevisions(ctx, id, revisions)
+	if err != nil {
+		return "", err
+	}
+
+	merged, err := resolver(winner, conflicts)
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: %w", id, err)
+	}
+	merged[metaID] = id
+	merged[metaRevision] = document.Revision()
+	delete(merged, metaConflicts)
+
+	if err := document.Store(ctx, merged); err != nil {
+		return "", fmt.Errorf("resolve %s: %w", id, err)
+	}
+
+	docs := []interface{}{}
+	for _, revision := range revisions {
+		docs = append(docs, map[string]interface{}{metaID: id, metaRevision: revision, metaDeleted: true})
+	}
+	results, err := db.BulkDocs(ctx, docs)
+	if err != nil {
+		return document.Revision(), fmt.Errorf("resolve %s: %w", id, err)
+	}
+	for index, result := range results {
+		if err := bulkResultError(result); err != nil {
+			return document.Revision(), fmt.Errorf("resolve %s: delete %s: %w", id, revisions[index], err)
+		}
+	}
+	return document.Revision(), nil
+}
+
+// openRevisions fetches the provided revisions of a document.
+func (db *Database) openRevisions(ctx context.Context, id string, revisions []string) ([]map[string]interface{}, error) {
+	query := url.Values{}
+	if err := setJSONQuery(query, "open_revs", revisions); err != nil {
+		return nil, err
+	}
+
+	r := []struct {
+		OK      map[string]interface{} `json:"ok"`
+		Missing string                 `json:"missing"`
+	}{}
+	if err := db.requestJSON(ctx, http.MethodGet, withQuery(documentPath(db.name, id), query), nil, nil, &r); err != nil {
+		return nil, err
+	}
+
+	documents := []map[string]interface{}{}
+	for _, item := range r {
+		if item.OK != nil {
+			documents = append(documents, item.OK)
+		}
+	}
+	return documents, nil
+}
+
+// LastWriteWins returns a resolver that picks the revision with the latest timestamp in the provided field.
+// Timestamps can be RFC 3339 strings or numbers. If timestamps are equal or missing, the winning revision is kept.
+func LastWriteWins(field string) Resolver {
+	return func(winner map[string]interface{}, conflicts []map[string]interface{}) (map[string]interface{}, error) {
+		latest := winner
+		for _, conflict := range conflicts {
+			if compareTimestamps(conflict[field], latest[field]) > 0 {
+				latest = conflict
+			}
+		}
+		return copyDocument(latest), nil
+	}
+}
+
+// FieldMerger defines a function that merges the values of a field. The values are ordered starting with
+// the winner's value. Revisions without the field are skipped.
+type FieldMerger func(values []interface{}) interface{}
+
+// FieldMerge returns a resolver that merges the documents field by field. Fields with a merger are merged
+// using it, the other fields take the winner's value or the value of the first conflicting revision that
+// contains the field.
+func FieldMerge(mergers map[string]FieldMerger) Resolver {
+	return func(winner map[string]interface{}, conflicts []map[string]interface{}) (map[string]interface{}, error) {
+		documents := append([]map[string]interface{}{winner}, conflicts...)
+
+		fields := []string{}
+		values := map[string][]interface{}{}
+		for _, document := range documents {
+			for field, value := range document {
+				if field == metaID || field == metaRevision {
+					continue
+				}
+				if _, ok := values[field]; !ok {
+					fields = append(fields, field)
+				}
+				values[field] = append(values[field], value)
+			}
+		}
+		sort.Strings(fields)
+
+		merged := map[string]interface{}{}
+		for _, field := range fields {
+			if merger, ok := mergers[field]; ok {
+				merged[field] = merger(values[field])
+				continue
+			}
+			merged[field] = values[field][0]
+		}
+		return merged, nil
+	}
+}
+
+func compareTimestamps(a, b interface{}) int {
+	ta, okA := timestamp(a)
+	tb, okB := timestamp(b)
+	switch {
+	case !okA && !okB:
+		return 0
+	case !okB:
+		return 1
+	case !okA:
+		return -1
+	case ta > tb:
+		return 1
+	case ta < tb:
+		return -1
+	}
+	return 0
+}
+
+func timestamp(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return float64(t.UnixNano()), true
+		}
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+func copyDocument(document map[string]interface{}) map[string]interface{} {
+	c := make(map[string]interface{}, len(document))
+	for key, value := range document {
+		c[key] = value
+	}
+	return c
+}
+
+func stringSlice(value interface{}) []string {
+	values, _ := value.([]interface{})
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -0,0 +1,172 @@
+package couchdb_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+)
+
+func TestConflict(t *testing.T) {
+	e := setUpTestEnvironment(t)
+	defer e.tearDown()
+
+	db := couchdb.NewDatabase(e.client, "test")
+	require.NoError(t, db.Create(e.ctx))
+	defer db.Delete(e.ctx)
+
+	createConflict := func(t *testing.T, id string) {
+		results, err := db.BulkDocs(e.ctx, []interface{}{
+			map[string]interface{}{"_id": id, "_rev": "1-aaaa", "name": "a", "modified": "2021-01-01T00:00:00Z"},
+			map[string]interface{}{"_id": id, "_rev": "1-bbbb", "name": "b", "modified": "2021-01-02T00:00:00Z", "extra": true},
+		}, couchdb.WithNewEdits(false))
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+	}
+
+	t.Run("ConflictedDocuments", func(t *testing.T) {
+		createConflict(t, "listed")
+		require.NoError(t, couchdb.NewDocument(db, "clean", "").Store(e.ctx, map[string]interface{}{"test": "value"}))
+
+		documents, err := db.ConflictedDocuments(e.ctx)
+		require.NoError(t, err)
+
+		require.Len(t, documents, 1)
+		assert.Equal(t, "listed", documents[0].ID)
+		assert.Equal(t, "1-bbbb", documents[0].Revision)
+		assert.Equal(t, []string{"1-aaaa"}, documents[0].Conflicts)
+	})
+
+	t.Run("ResolveLastWriteWins", func(t *testing.T) {
+		createConflict(t, "lww")
+
+		revision, err := db.Resolve(e.ctx, "lww", couchdb.LastWriteWins("modified"))
+		require.NoError(t, err)
+		assert.Regexp(t, `^2\-[0-9a-f]+$`, revision)
+
+		data := map[string]interface{}{}
+		require.NoError(t, couchdb.NewDocument(db, "lww", "").Fetch(e.ctx, &data, couchdb.WithConflicts()))
+
+		assert.Equal(t, "b", data["name"])
+		assert.NotContains(t, data, "_conflicts")
+	})
+
+	t.Run("ResolveFieldMerge", func(t *testing.T) {
+		createConflict(t, "merge")
+
+		_, err := db.Resolve(e.ctx, "merge", couchdb.FieldMerge(nil))
+		require.NoError(t, err)
+
+		data := map[string]interface{}{}
+		require.NoError(t, couchdb.NewDocument(db, "merge", "").Fetch(e.ctx, &data, couchdb.WithConflicts()))
+
+		assert.Equal(t, "b", data["name"])
+		assert.Equal(t, true, data["extra"])
+		assert.NotContains(t, data, "_conflicts")
+	})
+
+	t.Run("ResolveWithoutConflicts", func(t *testing.T) {
+		document := couchdb.NewDocument(db, "", "")
+		require.NoError(t, document.Store(e.ctx, map[string]interface{}{"test": "value"}))
+
+		revision, err := db.Resolve(e.ctx, document.ID(), couchdb.FieldMerge(nil))
+		require.NoError(t, err)
+		assert.Equal(t, document.Revision(), revision)
+	})
+}
+
+func TestResolvers(t *testing.T) {
+	winner := map[string]interface{}{"_id": "a", "_rev": "1-b", "name": "winner", "modified": "2021-01-01T00:00:00Z", "tags": []interface{}{"x"}}
+	conflicts := []map[string]interface{}{
+		{"_id": "a", "_rev": "1-a", "name": "loser", "modified": "2021-01-02T00:00:00Z", "tags": []interface{}{"y"}, "extra": 1.0},
+	}
+
+	t.Run("LastWriteWins", func(t *testing.T) {
+		merged, err := couchdb.LastWriteWins("modified")(winner, conflicts)
+		require.NoError(t, err)
+		assert.Equal(t, "loser", merged["name"])
+
+		merged, err = couchdb.LastWriteWins("missing")(winner, conflicts)
+		require.NoError(t, err)
+		assert.Equal(t, "winner", merged["name"])
+	})
+
+	t.Run("FieldMerge", func(t *testing.T) {
+		merged, err := couchdb.FieldMerge(map[string]couchdb.FieldMerger{
+			"tags": func(values []interface{}) interface{} {
+				union := []interface{}{}
+				for _, value := range values {
+					union = append(union, value.([]interface{})...)
+				}
+				return union
+			},
+		})(winner, conflicts)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]interface{}{
+			"name":     "winner",
+			"modified": "2021-01-01T00:00:00Z",
+			"tags":     []interface{}{"x", "y"},
+			"extra":    1.0,
+		}, merged)
+	})
+}
+
+func TestResolveStub(t *testing.T) {
+	newClient := func(t *testing.T, putStatus int, deleteResult string) (*couchdb.Client, *stubHTTPClient) {
+		client, httpClient := newStubClient(t)
+		httpClient.handle("http://a/test/abc", func(request stubRequest) stubResponse {
+			switch {
+			case request.method == http.MethodPut && putStatus == http.StatusCreated:
+				return stubResponse{statusCode: putStatus, body: `{"ok":true,"id":"abc","rev":"3-c"}`}
+			case request.method == http.MethodPut:
+				return stubResponse{statusCode: putStatus, body: `{"error":"conflict","reason":"Document update conflict."}`}
+			case strings.Contains(request.url, "open_revs"):
+				return stubResponse{statusCode: 200, body: `[{"ok":{"_id":"abc","_rev":"2-b","test":"loser"}}]`}
+			}
+			return stubResponse{
+				statusCode: 200,
+				header:     http.Header{"Etag": []string{`"2-a"`}},
+				body:       `{"_id":"abc","_rev":"2-a","test":"winner","_conflicts":["2-b"]}`,
+			}
+		})
+		httpClient.handle("http://a/test/_bulk_docs", jsonResponse(201, deleteResult))
+
+		return client, httpClient
+	}
+
+	t.Run("WinnerConflict", func(t *testing.T) {
+		client, httpClient := newClient(t, http.StatusConflict, `[]`)
+
+		_, err := couchdb.NewDatabase(client, "test").Resolve(context.Background(), "abc", couchdb.LastWriteWins("updated"))
+		assert.ErrorIs(t, err, couchdb.ErrConflict)
+		assert.NotContains(t, httpClient.urls(), "http://a/test/_bulk_docs")
+	})
+
+	t.Run("DeletionForbidden", func(t *testing.T) {
+		client, _ := newClient(t, http.StatusCreated, `[{"id":"abc","error":"forbidden","reason":"read only"}]`)
+
+		revision, err := couchdb.NewDatabase(client, "test").Resolve(context.Background(), "abc", couchdb.LastWriteWins("updated"))
+		assert.ErrorIs(t, err, couchdb.ErrForbidden)
+		assert.NotErrorIs(t, err, couchdb.ErrConflict)
+		assert.Equal(t, "3-c", revision)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		client, httpClient := newClient(t, http.StatusCreated, `[{"ok":true,"id":"abc","rev":"3-d"}]`)
+
+		revision, err := couchdb.NewDatabase(client, "test").Resolve(context.Background(), "abc", couchdb.LastWriteWins("updated"))
+		require.NoError(t, err)
+		assert.Equal(t, "3-c", revision)
+
+		requests := httpClient.requests
+		assert.Equal(t, http.MethodPut, requests[len(requests)-2].method)
+		assert.Equal(t, "2-a", requests[len(requests)-2].header.Get("If-Match"))
+		assert.JSONEq(t, `{"docs":[{"_id":"abc","_rev":"2-b","_deleted":true}]}`, requests[len(requests)-1].body)
+	})
+}
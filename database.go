@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/simia-tech/couchdb/value"
@@ -13,6 +14,8 @@ import (
 var (
 	ErrDatabaseAlreadyExists = errors.New("database already exists")
 	ErrDatabaseDoesNotExists = errors.New("database does not exists")
+	ErrUnsupportedFeature    = errors.New("unsupported feature")
+	ErrDatabaseMismatch      = errors.New("database mismatch")
 )
 
 // Database implements all methods on a couchdb database.
@@ -29,10 +32,22 @@ func NewDatabase(client *Client, name string) *Database {
 	}
 }
 
-// Create creates the database.
-func (db *Database) Create(ctx context.Context) error {
+// Create creates the database. A partitioned database can only be created, if the instance reports
+// the `partitioned` feature. The revision limit, the security object and the design documents of the
+// options are stored right after the creation.
+func (db *Database) Create(ctx context.Context, options ...CreateOption) error {
+	c := creationOf(options)
+	if c.partitioned != nil && *c.partitioned {
+		if err := db.client.requireFeature(ctx, featurePartitioned); err != nil {
+			return err
+		}
+	}
+
 	r := value.Status{}
-	if err := db.client.requestJSON(ctx, http.MethodPut, "/"+db.name, nil, nil, &r); err != nil {
+	if err := db.requestJSON(ctx, http.MethodPut, withQuery(db.path(), c.query()), nil, nil, &r); err != nil {
+		if errors.Is(err, ErrPreconditionFailed) {
+			return fmt.Errorf("create database %s: %w", db.name, ErrDatabaseAlreadyExists)
+		}
 		return err
 	}
 	if !r.OK {
@@ -43,13 +58,50 @@ func (db *Database) Create(ctx context.Context) error {
 			return fmt.Errorf("unknown error %s: %s", r.Error, r.Reason)
 		}
 	}
+
+	if c.revsLimit > 0 {
+		if err := db.SetRevsLimit(ctx, c.revsLimit); err != nil {
+			return fmt.Errorf("revs limit: %w", err)
+		}
+	}
+	if c.security != nil {
+		if err := db.SetSecurity(ctx, *c.security); err != nil {
+			return fmt.Errorf("security: %w", err)
+		}
+	}
+	for _, dd := range c.designDocuments {
+		if err := NewDocument(db, dd.id, "").Store(ctx, dd.document); err != nil {
+			return fmt.Errorf("design document %s: %w", dd.id, err)
+		}
+	}
 	return nil
 }
 
+// Exists returns true if the database exists.
+func (db *Database) Exists(ctx context.Context) (bool, error) {
+	statusCode, _, responseReader, err := db.request(ctx, http.MethodHead, db.path(), nil, nil)
+	if err != nil {
+		return false, err
+	}
+	responseReader.Close()
+
+	switch statusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("database %s: %w", db.name, statusError(statusCode))
+	}
+}
+
 // Delete deletes the database.
 func (db *Database) Delete(ctx context.Context) error {
 	r := value.Status{}
-	if err := db.client.requestJSON(ctx, http.MethodDelete, "/"+db.name, nil, nil, &r); err != nil {
+	if err := db.requestJSON(ctx, http.MethodDelete, db.path(), nil, nil, &r); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return fmt.Errorf("delete database %s: %w", db.name, ErrDatabaseDoesNotExists)
+		}
 		return err
 	}
 	if !r.OK {
@@ -66,8 +118,68 @@ func (db *Database) Delete(ctx context.Context) error {
 // Info fetches infos about the database.
 func (db *Database) Info(ctx context.Context) (value.DatabaseInfo, error) {
 	r := value.DatabaseInfo{}
-	if err := db.client.requestJSON(ctx, http.MethodGet, "/"+db.name, nil, nil, &r); err != nil {
+	if err := db.requestJSON(ctx, http.MethodGet, db.path(), nil, nil, &r); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return r, fmt.Errorf("database %s: %w", db.name, ErrDatabaseDoesNotExists)
+		}
 		return r, err
 	}
 	return r, nil
 }
+
+// Name returns the database's name.
+func (db *Database) Name() string {
+	return db.name
+}
+
+// url returns the url of the database at the client's first endpoint.
+func (db *Database) url() string {
+	return db.client.baseURLs[0] + db.path()
+}
+
+// path returns the path to the database followed by the provided escaped segments.
+func (db *Database) path(segments ...string) string {
+	return databasePath(db.name, segments...)
+}
+
+// requestJSON validates the database name before it performs the request.
+func (db *Database) requestJSON(
+	ctx context.Context,
+	method,
+	path string,
+	header http.Header,
+	body,
+	responseBody interface{},
+) error {
+	_, err := db.requestJSONWithHeader(ctx, method, path, header, body, responseBody)
+	return err
+}
+
+// requestJSONWithHeader validates the database name before it performs the request.
+func (db *Database) requestJSONWithHeader(
+	ctx context.Context,
+	method,
+	path string,
+	header http.Header,
+	body,
+	responseBody interface{},
+) (http.Header, error) {
+	if err := ValidateDatabaseName(db.name); err != nil {
+		return nil, err
+	}
+	return db.client.requestJSONWithHeader(ctx, method, path, header, body, responseBody)
+}
+
+// request validates the database name before it performs the request.
+func (db *Database) request(
+	ctx context.Context,
+	method,
+	path string,
+	header http.Header,
+	body io.Reader,
+) (int, http.Header, io.ReadCloser, error) {
+	if err := ValidateDatabaseName(db.name); err != nil {
+		return 0, nil, nil, err
+	}
+	return db.client.request(ctx, method, path, header, body)
+}
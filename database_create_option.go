@@ -0,0 +1,95 @@
+package couchdb
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+// CreateOption defines a function that can modify the parameters of a database creation.
+type CreateOption func(*creation)
+
+type creation struct {
+	shards          uint
+	replicas        uint
+	partitioned     *bool
+	revsLimit       uint
+	security        *value.Security
+	designDocuments []designDocument
+}
+
+type designDocument struct {
+	id       string
+	document interface{}
+}
+
+// WithShards returns an option that sets the number of shards (q) of the database.
+func WithShards(value uint) CreateOption {
+	return func(c *creation) {
+		c.shards = value
+	}
+}
+
+// WithReplicas returns an option that sets the number of replicas (n) of each shard of the database.
+func WithReplicas(value uint) CreateOption {
+	return func(c *creation) {
+		c.replicas = value
+	}
+}
+
+// WithPartitioned returns an option that creates a partitioned database.
+func WithPartitioned() CreateOption {
+	return func(c *creation) {
+		partitioned := true
+		c.partitioned = &partitioned
+	}
+}
+
+// WithRevsLimit returns an option that sets the maximum number of revisions, that are tracked per document.
+func WithRevsLimit(value uint) CreateOption {
+	return func(c *creation) {
+		c.revsLimit = value
+	}
+}
+
+// WithSecurity returns an option that sets the security object of the database.
+func WithSecurity(security value.Security) CreateOption {
+	return func(c *creation) {
+		c.security = &security
+	}
+}
+
+// WithDesignDocument returns an option that stores the provided design document in the database. The id
+// can be provided with or without the `_design/` prefix.
+func WithDesignDocument(id string, document interface{}) CreateOption {
+	return func(c *creation) {
+		if !strings.HasPrefix(id, designPrefix) {
+			id = designPrefix + id
+		}
+		c.designDocuments = append(c.designDocuments, designDocument{id: id, document: document})
+	}
+}
+
+func creationOf(options []CreateOption) creation {
+	c := creation{}
+	for _, o := range options {
+		o(&c)
+	}
+	return c
+}
+
+func (c creation) query() url.Values {
+	query := url.Values{}
+	if c.shards > 0 {
+		query.Set("q", strconv.FormatUint(uint64(c.shards), 10))
+	}
+	if c.replicas > 0 {
+		query.Set("n", strconv.FormatUint(uint64(c.replicas), 10))
+	}
+	if c.partitioned != nil && *c.partitioned {
+		query.Set("partitioned", "true")
+	}
+	return query
+}
@@ -0,0 +1,133 @@
+package couchdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+// Ensure creates the database if it doesn't exist. If it exists, the partitioned and shard settings of the
+// options are verified and `ErrDatabaseMismatch` is returned if they differ. Settings without an option
+// aren't verified. Afterwards, the revision limit, the security object and the design documents of the
+// options are stored, if they differ from the existing ones. A newly created database gets them during the
+// creation, so they aren't compared again.
+func (db *Database) Ensure(ctx context.Context, options ...CreateOption) error {
+	c := creationOf(options)
+
+	exists, err := db.Exists(ctx)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		err := db.Create(ctx, options...)
+		if err == nil {
+			// The creation already stored the revision limit, the security object and the design documents.
+			return db.verify(ctx, c)
+		}
+		if !errors.Is(err, ErrDatabaseAlreadyExists) {
+			return err
+		}
+	}
+	if err := db.verify(ctx, c); err != nil {
+		return err
+	}
+
+	if c.revsLimit > 0 {
+		if err := db.reconcileRevsLimit(ctx, c.revsLimit); err != nil {
+			return fmt.Errorf("revs limit: %w", err)
+		}
+	}
+	if c.security != nil {
+		if err := db.reconcileSecurity(ctx, *c.security); err != nil {
+			return fmt.Errorf("security: %w", err)
+		}
+	}
+	for _, dd := range c.designDocuments {
+		if err := db.reconcileDesignDocument(ctx, dd.id, dd.document); err != nil {
+			return fmt.Errorf("design document %s: %w", dd.id, err)
+		}
+	}
+
+	return nil
+}
+
+func (db *Database) verify(ctx context.Context, c creation) error {
+	info, err := db.Info(ctx)
+	if err != nil {
+		return err
+	}
+	if c.partitioned != nil && info.Props.Partitioned != *c.partitioned {
+		return fmt.Errorf("%w: %s: partitioned is %t", ErrDatabaseMismatch, db.name, info.Props.Partitioned)
+	}
+	if c.shards > 0 && info.Cluster.Q != c.shards {
+		return fmt.Errorf("%w: %s: q is %d", ErrDatabaseMismatch, db.name, info.Cluster.Q)
+	}
+	return nil
+}
+
+func (db *Database) reconcileRevsLimit(ctx context.Context, limit uint) error {
+	existing, err := db.RevsLimit(ctx)
+	if err != nil {
+		return err
+	}
+	if existing == limit {
+		return nil
+	}
+	return db.SetRevsLimit(ctx, limit)
+}
+
+func (db *Database) reconcileSecurity(ctx context.Context, security value.Security) error {
+	existing, err := db.Security(ctx)
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(normalizeSecurity(existing), normalizeSecurity(security)) {
+		return nil
+	}
+	return db.SetSecurity(ctx, security)
+}
+
+func (db *Database) reconcileDesignDocument(ctx context.Context, id string, document interface{}) error {
+	codec := db.client.codec
+
+	buffer := &bytes.Buffer{}
+	if err := codec.Encode(buffer, document); err != nil {
+		return fmt.Errorf("encode: %w", err)
+	}
+	desired := map[string]interface{}{}
+	if err := codec.Decode(buffer, &desired); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+	delete(desired, metaID)
+	delete(desired, metaRevision)
+
+	existing := map[string]interface{}{}
+	d := NewDocument(db, id, "")
+	if err := d.Fetch(ctx, &existing); err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	delete(existing, metaID)
+	delete(existing, metaRevision)
+
+	if reflect.DeepEqual(existing, desired) {
+		return nil
+	}
+	return d.Store(ctx, desired)
+}
+
+// normalizeSecurity replaces missing names and roles by empty lists, so security objects can be compared.
+func normalizeSecurity(security value.Security) value.Security {
+	for _, group := range []*value.SecurityGroup{&security.Admins, &security.Members} {
+		if group.Names == nil {
+			group.Names = []string{}
+		}
+		if group.Roles == nil {
+			group.Roles = []string{}
+		}
+	}
+	return security
+}
@@ -0,0 +1,177 @@
+package couchdb_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+	"github.com/simia-tech/couchdb/value"
+)
+
+func TestDatabaseEnsure(t *testing.T) {
+	e := setUpTestEnvironment(t)
+	defer e.tearDown()
+
+	db := couchdb.NewDatabase(e.client, "test")
+	defer db.Delete(e.ctx)
+
+	security := value.Security{Members: value.SecurityGroup{Roles: []string{"tenant"}}}
+	designDocument := map[string]interface{}{
+		"views": map[string]interface{}{
+			"test": map[string]interface{}{"map": "function (doc) { emit(doc._id, null); }"},
+		},
+	}
+	options := []couchdb.CreateOption{
+		couchdb.WithShards(2),
+		couchdb.WithSecurity(security),
+		couchdb.WithDesignDocument("test", designDocument),
+	}
+
+	t.Run("Missing", func(t *testing.T) {
+		exists, err := db.Exists(e.ctx)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		require.NoError(t, db.Ensure(e.ctx, options...))
+
+		exists, err = db.Exists(e.ctx)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		s, err := db.Security(e.ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"tenant"}, s.Members.Roles)
+	})
+
+	t.Run("Existing", func(t *testing.T) {
+		document := couchdb.NewDocument(db, "_design/test", "")
+		require.NoError(t, document.Fetch(e.ctx, nil))
+		revision := document.Revision()
+
+		require.NoError(t, db.Ensure(e.ctx, options...))
+
+		require.NoError(t, document.Fetch(e.ctx, nil))
+		assert.Equal(t, revision, document.Revision())
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		err := db.Ensure(e.ctx, couchdb.WithShards(4))
+		assert.ErrorIs(t, err, couchdb.ErrDatabaseMismatch)
+	})
+}
+
+func TestDatabaseEnsureStub(t *testing.T) {
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/test", func(request stubRequest) stubResponse {
+		if request.method == http.MethodHead {
+			return stubResponse{statusCode: 200}
+		}
+		return stubResponse{statusCode: 200, body: `{"db_name":"test","cluster":{"q":2,"n":1}}`}
+	})
+	httpClient.handle("http://a/test/_security", func(request stubRequest) stubResponse {
+		if request.method == http.MethodPut {
+			return stubResponse{statusCode: 200, body: `{"ok":true}`}
+		}
+		return stubResponse{statusCode: 200, body: `{}`}
+	})
+	httpClient.handle("http://a/test/_design/test", func(request stubRequest) stubResponse {
+		if request.method == http.MethodPut {
+			return stubResponse{statusCode: 201, body: `{"ok":true,"id":"_design/test","rev":"2-b"}`}
+		}
+		return stubResponse{
+			statusCode: 200,
+			header:     http.Header{"Etag": []string{`"1-a"`}},
+			body:       `{"_id":"_design/test","_rev":"1-a","language":"javascript"}`,
+		}
+	})
+
+	db := couchdb.NewDatabase(client, "test")
+
+	t.Run("Unchanged", func(t *testing.T) {
+		httpClient.reset()
+		require.NoError(t, db.Ensure(context.Background(),
+			couchdb.WithShards(2),
+			couchdb.WithSecurity(value.Security{}),
+			couchdb.WithDesignDocument("test", map[string]interface{}{"language": "javascript"})))
+
+		for _, request := range httpClient.requests {
+			assert.NotEqual(t, http.MethodPut, request.method, request.url)
+		}
+	})
+
+	t.Run("Changed", func(t *testing.T) {
+		httpClient.reset()
+		require.NoError(t, db.Ensure(context.Background(),
+			couchdb.WithSecurity(value.Security{Admins: value.SecurityGroup{Names: []string{"admin"}}}),
+			couchdb.WithDesignDocument("_design/test", map[string]interface{}{"language": "query"})))
+
+		puts := []stubRequest{}
+		for _, request := range httpClient.requests {
+			if request.method == http.MethodPut {
+				puts = append(puts, request)
+			}
+		}
+		require.Len(t, puts, 2)
+		assert.Equal(t, "http://a/test/_security", puts[0].url)
+		assert.Equal(t, "http://a/test/_design/test", puts[1].url)
+		assert.Equal(t, "1-a", puts[1].header.Get("If-Match"))
+		assert.JSONEq(t, `{"language":"query"}`, puts[1].body)
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		err := db.Ensure(context.Background(), couchdb.WithPartitioned())
+		assert.ErrorIs(t, err, couchdb.ErrDatabaseMismatch)
+	})
+}
+
+func TestDatabaseCreatePreconditionFailed(t *testing.T) {
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/test", jsonResponse(412, `{"error":"file_exists","reason":"The database could not be created."}`))
+
+	err := couchdb.NewDatabase(client, "test").Create(context.Background())
+	assert.ErrorIs(t, err, couchdb.ErrDatabaseAlreadyExists)
+}
+
+func TestDatabaseCreateWithSecurityAndDesignDocument(t *testing.T) {
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/test", jsonResponse(201, `{"ok":true}`))
+	httpClient.handle("http://a/test/_security", jsonResponse(200, `{"ok":true}`))
+	httpClient.handle("http://a/test/_design/test", jsonResponse(201, `{"ok":true,"id":"_design/test","rev":"1-a"}`))
+
+	require.NoError(t, couchdb.NewDatabase(client, "test").Create(context.Background(),
+		couchdb.WithSecurity(value.Security{Admins: value.SecurityGroup{Names: []string{"admin"}}}),
+		couchdb.WithDesignDocument("test", map[string]interface{}{"language": "javascript"})))
+
+	require.Len(t, httpClient.requests, 3)
+	assert.Equal(t, "http://a/test/_security", httpClient.requests[1].url)
+	assert.Contains(t, httpClient.requests[1].body, `"admin"`)
+	assert.Equal(t, "http://a/test/_design/test", httpClient.requests[2].url)
+	assert.JSONEq(t, `{"language":"javascript"}`, httpClient.requests[2].body)
+}
+
+func TestDatabaseEnsurePartitionedWithoutOption(t *testing.T) {
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/test", func(request stubRequest) stubResponse {
+		if request.method == http.MethodHead {
+			return stubResponse{statusCode: 200}
+		}
+		return stubResponse{statusCode: 200, body: `{"db_name":"test","cluster":{"q":2},"props":{"partitioned":true}}`}
+	})
+
+	db := couchdb.NewDatabase(client, "test")
+	require.NoError(t, db.Ensure(context.Background()))
+	require.NoError(t, db.Ensure(context.Background(), couchdb.WithPartitioned()))
+}
+
+func TestDatabaseExistsStatusError(t *testing.T) {
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/test", func(stubRequest) stubResponse { return stubResponse{statusCode: 401} })
+
+	_, err := couchdb.NewDatabase(client, "test").Exists(context.Background())
+	assert.ErrorIs(t, err, couchdb.ErrUnauthorized)
+	assert.Contains(t, err.Error(), "test")
+}
@@ -1,12 +1,14 @@
 package couchdb_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/simia-tech/couchdb"
+	"github.com/simia-tech/couchdb/value"
 )
 
 func TestDatabase(t *testing.T) {
@@ -55,6 +57,61 @@ func TestDatabase(t *testing.T) {
 		assert.Equal(t, uint(2), di.Cluster.Q)
 		assert.Equal(t, uint(1), di.Cluster.W)
 		assert.Equal(t, uint(1), di.Cluster.R)
+		assert.NotEmpty(t, di.UpdateSequence)
+		assert.NotZero(t, di.Sizes.File)
+		assert.False(t, di.Props.Partitioned)
 	})
 
+	t.Run("AllDocs", func(t *testing.T) {
+		db := couchdb.NewDatabase(e.client, "test")
+		require.NoError(t, db.Create(e.ctx))
+		defer db.Delete(e.ctx)
+
+		for _, id := range []string{"a", "b", "c"} {
+			require.NoError(t, couchdb.NewDocument(db, id, "").Store(e.ctx, map[string]interface{}{"test": id}))
+		}
+
+		result, err := db.AllDocs(e.ctx, couchdb.WithStartKey("b"), couchdb.WithIncludeDocs())
+		require.NoError(t, err)
+
+		assert.Equal(t, uint(3), result.TotalRows)
+		require.Len(t, result.Rows, 2)
+		assert.Equal(t, "b", result.Rows[0].ID)
+		assert.JSONEq(t, `"b"`, string(result.Rows[0].Key))
+		assert.Contains(t, string(result.Rows[0].Doc), `"test":"b"`)
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		db := couchdb.NewDatabase(e.client, "test")
+		require.NoError(t, db.Create(e.ctx))
+		defer db.Delete(e.ctx)
+
+		for _, id := range []string{"a", "b", "c"} {
+			require.NoError(t, couchdb.NewDocument(db, id, "").Store(e.ctx, map[string]interface{}{"test": id}))
+		}
+
+		result, err := db.Find(e.ctx, value.FindQuery{
+			Selector: map[string]interface{}{"test": "c"},
+			Fields:   []string{"_id"},
+		})
+		require.NoError(t, err)
+
+		require.Len(t, result.Docs, 1)
+		assert.JSONEq(t, `{"_id":"c"}`, string(result.Docs[0]))
+	})
+}
+
+func TestDatabaseInfoWithNumericSequences(t *testing.T) {
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/test", jsonResponse(200, `{"db_name":"test","update_seq":42,"purge_seq":0,`+
+		`"compacted_seq":40,"instance_start_time":"0","sizes":{"file":3,"external":2,"active":1},"props":{"partitioned":true}}`))
+
+	di, err := couchdb.NewDatabase(client, "test").Info(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, value.Seq("42"), di.UpdateSequence)
+	assert.Equal(t, value.Seq("0"), di.PurgeSequence)
+	assert.Equal(t, value.Seq("40"), di.CompactedSequence)
+	assert.Equal(t, value.Sizes{File: 3, External: 2, Active: 1}, di.Sizes)
+	assert.True(t, di.Props.Partitioned)
 }
@@ -0,0 +1,48 @@
+package couchdb
+
+import (
+	"context"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+// DBUpdatesFeed implements an iterator over the entries of the database updates feed.
+type DBUpdatesFeed struct {
+	feed *feed[value.DBUpdate]
+}
+
+// DBUpdates requests the feed of database creations, updates and deletions. The returned feed has to be
+// closed by the caller. Continuous feeds are streamed until the context is cancelled, the timeout is
+// reached or the feed is closed.
+func (c *Client) DBUpdates(ctx context.Context, options ...FeedOption) (*DBUpdatesFeed, error) {
+	f, err := openFeed[value.DBUpdate](ctx, c.codec, c.requestJSON, "/_db_updates", options)
+	if err != nil {
+		return nil, err
+	}
+	return &DBUpdatesFeed{feed: f}, nil
+}
+
+// Next advances to the next update and returns false if there are no more updates or an error occurred.
+func (uf *DBUpdatesFeed) Next() bool {
+	return uf.feed.next()
+}
+
+// Update returns the current update.
+func (uf *DBUpdatesFeed) Update() value.DBUpdate {
+	return uf.feed.current
+}
+
+// LastSeq returns the last sequence of the feed. It's available after `Next` returned false.
+func (uf *DBUpdatesFeed) LastSeq() value.Seq {
+	return uf.feed.lastSeq
+}
+
+// Err returns the error that occurred while reading the feed.
+func (uf *DBUpdatesFeed) Err() error {
+	return uf.feed.err
+}
+
+// Close closes the feed.
+func (uf *DBUpdatesFeed) Close() error {
+	return uf.feed.close()
+}
@@ -0,0 +1,88 @@
+package couchdb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+	"github.com/simia-tech/couchdb/value"
+)
+
+func TestDBUpdates(t *testing.T) {
+	e := setUpTestEnvironment(t)
+	defer e.tearDown()
+
+	feed, err := e.client.DBUpdates(e.ctx)
+	require.NoError(t, err)
+	require.NoError(t, feed.Close())
+	since := feed.LastSeq()
+
+	ctx, cancel := context.WithTimeout(e.ctx, 10*time.Second)
+	defer cancel()
+
+	feed, err = e.client.DBUpdates(ctx,
+		couchdb.WithFeed(couchdb.FeedContinuous),
+		couchdb.WithSince(since),
+		couchdb.WithHeartbeat(time.Second))
+	require.NoError(t, err)
+	defer feed.Close()
+
+	db := couchdb.NewDatabase(e.client, "test")
+	require.NoError(t, db.Create(e.ctx))
+	defer db.Delete(e.ctx)
+
+	for feed.Next() {
+		if update := feed.Update(); update.DBName == "test" {
+			assert.Equal(t, value.DBUpdateCreated, update.Type)
+			return
+		}
+	}
+	require.NoError(t, feed.Err())
+	t.Fatal("missing update")
+}
+
+func TestDBUpdatesStub(t *testing.T) {
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/_db_updates", func(request stubRequest) stubResponse {
+		if request.url == "http://a/_db_updates?feed=continuous&since=now" {
+			return stubResponse{statusCode: 200, body: "{\"db_name\":\"one\",\"type\":\"created\",\"seq\":\"1-a\"}\n\n" +
+				"{\"db_name\":\"one\",\"type\":\"deleted\",\"seq\":\"2-a\"}\n"}
+		}
+		return stubResponse{statusCode: 200, body: `{"results":[{"db_name":"one","type":"updated","seq":"1-a"}],"last_seq":"1-a"}`}
+	})
+
+	t.Run("Normal", func(t *testing.T) {
+		feed, err := client.DBUpdates(context.Background())
+		require.NoError(t, err)
+		defer feed.Close()
+
+		require.True(t, feed.Next())
+		assert.Equal(t, value.DBUpdate{DBName: "one", Type: value.DBUpdateUpdated, Seq: "1-a"}, feed.Update())
+		assert.False(t, feed.Next())
+		assert.NoError(t, feed.Err())
+		assert.Equal(t, value.Seq("1-a"), feed.LastSeq())
+	})
+
+	t.Run("Continuous", func(t *testing.T) {
+		feed, err := client.DBUpdates(context.Background(),
+			couchdb.WithFeed(couchdb.FeedContinuous),
+			couchdb.WithSince("now"))
+		require.NoError(t, err)
+		defer feed.Close()
+
+		updates := []value.DBUpdate{}
+		for feed.Next() {
+			updates = append(updates, feed.Update())
+		}
+		require.NoError(t, feed.Err())
+
+		assert.Equal(t, []value.DBUpdate{
+			{DBName: "one", Type: value.DBUpdateCreated, Seq: "1-a"},
+			{DBName: "one", Type: value.DBUpdateDeleted, Seq: "2-a"},
+		}, updates)
+	})
+}
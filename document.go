@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"net/url"
+	"strings"
 )
 
 // Various errors.
 var (
-	ErrMissingID = errors.New("missing id")
+	ErrMissingID       = errors.New("missing id")
+	ErrMissingRevision = errors.New("missing revision")
 )
 
 // Document implements all methods on a couchdb document.
@@ -38,7 +41,8 @@ func (d *Document) Revision() string {
 }
 
 // Store saves the document to the database. If the id is empty, it will be save at
-// a generated id.
+// a generated id. The data can also be a `json.RawMessage` or an `io.Reader` that provides
+// the encoded document.
 func (d *Document) Store(ctx context.Context, data interface{}) error {
 	if d.id == "" {
 		return d.storeWithoutID(ctx, data)
@@ -46,16 +50,51 @@ func (d *Document) Store(ctx context.Context, data interface{}) error {
 	return d.storeWithID(ctx, data)
 }
 
-// Fetch loads the document from the database.
-func (d *Document) Fetch(ctx context.Context, data interface{}) error {
+// Fetch loads the document from the database and updates the revision. If data is a `*json.RawMessage`,
+// it receives the encoded document, if it's an `*io.ReadCloser`, it receives the document stream, which
+// has to be closed by the caller. Attachments are returned as stubs unless requested otherwise.
+func (d *Document) Fetch(ctx context.Context, data interface{}, options ...FetchOption) error {
 	if d.id == "" {
 		return ErrMissingID
 	}
 
-	if err := d.database.client.requestJSON(ctx, http.MethodGet, "/"+d.database.name+"/"+d.id, nil, nil, data); err != nil {
+	query, err := fetchQuery(options)
+	if err != nil {
 		return err
 	}
 
+	header, err := d.database.requestJSONWithHeader(ctx, http.MethodGet, withQuery(d.path(), query), nil, nil, data)
+	if err != nil {
+		return err
+	}
+	if etag := header.Get("ETag"); etag != "" {
+		d.revision = strings.Trim(etag, `"`)
+	}
+
+	return nil
+}
+
+// Delete deletes the document from the database. The revision is updated to the revision
+// of the deletion tombstone.
+func (d *Document) Delete(ctx context.Context) error {
+	if d.id == "" {
+		return ErrMissingID
+	}
+	if d.revision == "" {
+		return ErrMissingRevision
+	}
+
+	r := struct {
+		OK       bool   `json:"ok"`
+		ID       string `json:"id"`
+		Revision string `json:"rev"`
+	}{}
+	if err := d.database.requestJSON(ctx, http.MethodDelete, withQuery(d.path(), url.Values{"rev": {d.revision}}), nil, nil, &r); err != nil {
+		return err
+	}
+	if r.OK {
+		d.revision = r.Revision
+	}
 	return nil
 }
 
@@ -65,7 +104,7 @@ func (d *Document) storeWithoutID(ctx context.Context, data interface{}) error {
 		ID       string `json:"id"`
 		Revision string `json:"rev"`
 	}{}
-	if err := d.database.client.requestJSON(ctx, http.MethodPost, "/"+d.database.name, nil, data, &r); err != nil {
+	if err := d.database.requestJSON(ctx, http.MethodPost, d.database.path(), nil, data, &r); err != nil {
 		return err
 	}
 	if r.OK {
@@ -85,7 +124,7 @@ func (d *Document) storeWithID(ctx context.Context, data interface{}) error {
 		ID       string `json:"id"`
 		Revision string `json:"rev"`
 	}{}
-	if err := d.database.client.requestJSON(ctx, http.MethodPut, "/"+d.database.name+"/"+d.id, header, data, &r); err != nil {
+	if err := d.database.requestJSON(ctx, http.MethodPut, d.path(), header, data, &r); err != nil {
 		return err
 	}
 	if r.OK {
@@ -93,3 +132,8 @@ func (d *Document) storeWithID(ctx context.Context, data interface{}) error {
 	}
 	return nil
 }
+
+// path returns the path to the document followed by the provided escaped segments.
+func (d *Document) path(segments ...string) string {
+	return documentPath(d.database.name, d.id, segments...)
+}
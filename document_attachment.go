@@ -0,0 +1,174 @@
+package couchdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+// AttachmentOption defines a function that can modify the header of an attachment request.
+type AttachmentOption func(http.Header)
+
+// WithRange returns an option that requests the bytes from start to end (inclusive) of an attachment. A
+// negative end requests all bytes from start on.
+func WithRange(start, end int64) AttachmentOption {
+	return func(h http.Header) {
+		if end < 0 {
+			h.Set("Range", fmt.Sprintf("bytes=%d-", start))
+			return
+		}
+		h.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end))
+	}
+}
+
+// WithContentLength returns an option that sets the length of an uploaded attachment. Without it, the
+// attachment is uploaded using chunked transfer encoding unless the reader reports its length via a
+// `Len() int` method, like `*bytes.Reader`, `*bytes.Buffer` and `*strings.Reader` do.
+func WithContentLength(value int64) AttachmentOption {
+	return func(h http.Header) {
+		h.Set("Content-Length", strconv.FormatInt(value, 10))
+	}
+}
+
+// PutAttachment uploads the attachment with the provided name from the reader. The data is streamed
+// to the database and the document's revision is updated.
+func (d *Document) PutAttachment(
+	ctx context.Context,
+	name, contentType string,
+	reader io.Reader,
+	options ...AttachmentOption,
+) error {
+	if d.id == "" {
+		return ErrMissingID
+	}
+
+	header := attachmentHeader(options)
+	header.Set("Accept", "application/json")
+	header.Set("Content-Type", contentType)
+
+	statusCode, _, responseReader, err := d.database.request(ctx, http.MethodPut, d.revisedAttachmentPath(name), header, reader)
+	if err != nil {
+		return err
+	}
+
+	return d.updateRevision(statusCode, responseReader)
+}
+
+// Attachment downloads the attachment with the provided name. The returned reader has to be closed by the caller.
+func (d *Document) Attachment(
+	ctx context.Context,
+	name string,
+	options ...AttachmentOption,
+) (io.ReadCloser, value.AttachmentInfo, error) {
+	if d.id == "" {
+		return nil, value.AttachmentInfo{}, ErrMissingID
+	}
+
+	statusCode, responseHeader, responseReader, err := d.database.request(ctx, http.MethodGet, d.attachmentPath(name), attachmentHeader(options), nil)
+	if err != nil {
+		return nil, value.AttachmentInfo{}, err
+	}
+	if err := checkJSONError(statusCode, responseReader); err != nil {
+		responseReader.Close()
+		return nil, value.AttachmentInfo{}, fmt.Errorf("attachment %s: %w", name, err)
+	}
+
+	return responseReader, attachmentInfo(responseHeader), nil
+}
+
+// AttachmentMeta fetches the infos about the attachment with the provided name without downloading it.
+func (d *Document) AttachmentMeta(ctx context.Context, name string) (value.AttachmentInfo, error) {
+	if d.id == "" {
+		return value.AttachmentInfo{}, ErrMissingID
+	}
+
+	statusCode, responseHeader, responseReader, err := d.database.request(ctx, http.MethodHead, d.attachmentPath(name), http.Header{}, nil)
+	if err != nil {
+		return value.AttachmentInfo{}, err
+	}
+	responseReader.Close()
+	if err := checkJSONError(statusCode, nil); err != nil {
+		return value.AttachmentInfo{}, fmt.Errorf("attachment %s: %w", name, err)
+	}
+
+	return attachmentInfo(responseHeader), nil
+}
+
+// DeleteAttachment deletes the attachment with the provided name and updates the document's revision.
+func (d *Document) DeleteAttachment(ctx context.Context, name string) error {
+	if d.id == "" {
+		return ErrMissingID
+	}
+	if d.revision == "" {
+		return ErrMissingRevision
+	}
+
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+
+	statusCode, _, responseReader, err := d.database.request(ctx, http.MethodDelete, d.revisedAttachmentPath(name), header, nil)
+	if err != nil {
+		return err
+	}
+
+	return d.updateRevision(statusCode, responseReader)
+}
+
+func (d *Document) attachmentPath(name string) string {
+	return d.path(escapeAttachmentName(name))
+}
+
+// revisedAttachmentPath returns the attachment path including the document's current revision.
+func (d *Document) revisedAttachmentPath(name string) string {
+	if d.revision == "" {
+		return d.attachmentPath(name)
+	}
+	return withQuery(d.attachmentPath(name), url.Values{"rev": {d.revision}})
+}
+
+func (d *Document) updateRevision(statusCode int, responseReader io.ReadCloser) error {
+	r := struct {
+		OK       bool   `json:"ok"`
+		ID       string `json:"id"`
+		Revision string `json:"rev"`
+	}{}
+	if err := d.database.client.decodeJSON(statusCode, responseReader, &r); err != nil {
+		return err
+	}
+	if r.OK {
+		d.revision = r.Revision
+	}
+	return nil
+}
+
+func attachmentHeader(options []AttachmentOption) http.Header {
+	header := http.Header{}
+	for _, o := range options {
+		o(header)
+	}
+	return header
+}
+
+func attachmentInfo(header http.Header) value.AttachmentInfo {
+	ai := value.AttachmentInfo{
+		ContentType:     header.Get("Content-Type"),
+		ContentEncoding: header.Get("Content-Encoding"),
+		Length:          -1,
+		ContentRange:    header.Get("Content-Range"),
+	}
+	if length, err := strconv.ParseInt(header.Get("Content-Length"), 10, 64); err == nil {
+		ai.Length = length
+	}
+	if digest := header.Get("Content-MD5"); digest != "" {
+		ai.Digest = "md5-" + digest
+	} else if etag := strings.Trim(header.Get("ETag"), `"`); etag != "" {
+		ai.Digest = "md5-" + etag
+	}
+	return ai
+}
@@ -0,0 +1,129 @@
+package couchdb_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+)
+
+func TestDocumentAttachment(t *testing.T) {
+	e := setUpTestEnvironment(t)
+	defer e.tearDown()
+
+	db := couchdb.NewDatabase(e.client, "test")
+	require.NoError(t, db.Create(e.ctx))
+	defer db.Delete(e.ctx)
+
+	t.Run("Put", func(t *testing.T) {
+		t.Run("NewDocument", func(t *testing.T) {
+			document := couchdb.NewDocument(db, "new", "")
+
+			require.NoError(t, document.PutAttachment(e.ctx, "test.txt", "text/plain", strings.NewReader("test")))
+
+			assert.Regexp(t, `^1\-[0-9a-f]+$`, document.Revision())
+		})
+
+		t.Run("ExistingDocument", func(t *testing.T) {
+			document := couchdb.NewDocument(db, "", "")
+			require.NoError(t, document.Store(e.ctx, map[string]interface{}{"test": "value"}))
+
+			require.NoError(t, document.PutAttachment(e.ctx, "test.txt", "text/plain", strings.NewReader("test")))
+			require.NoError(t, document.PutAttachment(e.ctx, "dir/test.txt", "text/plain", strings.NewReader("other")))
+
+			assert.Regexp(t, `^3\-[0-9a-f]+$`, document.Revision())
+		})
+
+		t.Run("OutdatedRevision", func(t *testing.T) {
+			document := couchdb.NewDocument(db, "", "")
+			require.NoError(t, document.Store(e.ctx, map[string]interface{}{"test": "value"}))
+
+			otherDocument := couchdb.NewDocument(db, document.ID(), document.Revision())
+			require.NoError(t, document.Store(e.ctx, map[string]interface{}{"test": "another value"}))
+
+			err := otherDocument.PutAttachment(e.ctx, "test.txt", "text/plain", strings.NewReader("test"))
+			assert.ErrorIs(t, err, couchdb.ErrConflict)
+		})
+	})
+
+	document := couchdb.NewDocument(db, "attachments", "")
+	require.NoError(t, document.PutAttachment(e.ctx, "test.txt", "text/plain", strings.NewReader("test content")))
+
+	t.Run("Get", func(t *testing.T) {
+		reader, info, err := document.Attachment(e.ctx, "test.txt")
+		require.NoError(t, err)
+		defer reader.Close()
+
+		data, err := ioutil.ReadAll(reader)
+		require.NoError(t, err)
+
+		assert.Equal(t, "test content", string(data))
+		assert.Equal(t, "text/plain", info.ContentType)
+		assert.Equal(t, int64(12), info.Length)
+		assert.Equal(t, "md5-lHP90NiApDwht3eNNIchVw==", info.Digest)
+	})
+
+	t.Run("GetRange", func(t *testing.T) {
+		reader, info, err := document.Attachment(e.ctx, "test.txt", couchdb.WithRange(5, -1))
+		require.NoError(t, err)
+		defer reader.Close()
+
+		data, err := ioutil.ReadAll(reader)
+		require.NoError(t, err)
+
+		assert.Equal(t, "content", string(data))
+		assert.Equal(t, int64(7), info.Length)
+		assert.Equal(t, "bytes 5-11/12", info.ContentRange)
+	})
+
+	t.Run("GetMissing", func(t *testing.T) {
+		_, _, err := document.Attachment(e.ctx, "missing.txt")
+		assert.ErrorIs(t, err, couchdb.ErrNotFound)
+	})
+
+	t.Run("Meta", func(t *testing.T) {
+		info, err := document.AttachmentMeta(e.ctx, "test.txt")
+		require.NoError(t, err)
+
+		assert.Equal(t, "text/plain", info.ContentType)
+		assert.Equal(t, int64(12), info.Length)
+		assert.Equal(t, "md5-lHP90NiApDwht3eNNIchVw==", info.Digest)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		document := couchdb.NewDocument(db, "delete", "")
+		require.NoError(t, document.PutAttachment(e.ctx, "test.txt", "text/plain", strings.NewReader("test")))
+
+		require.NoError(t, document.DeleteAttachment(e.ctx, "test.txt"))
+
+		assert.Regexp(t, `^2\-[0-9a-f]+$`, document.Revision())
+
+		_, err := document.AttachmentMeta(e.ctx, "test.txt")
+		assert.ErrorIs(t, err, couchdb.ErrNotFound)
+	})
+}
+
+func TestPutAttachmentContentLength(t *testing.T) {
+	server, requests := newRecordingServer(t, `{"ok":true,"id":"test","rev":"2-b"}`)
+
+	client, err := couchdb.NewClient(server.URL)
+	require.NoError(t, err)
+	defer client.Close()
+
+	document := couchdb.NewDocument(couchdb.NewDatabase(client, "test"), "test", "1-a")
+	require.NoError(t, document.PutAttachment(context.Background(), "test.txt", "text/plain", bytes.NewReader([]byte("content"))))
+	require.NoError(t, document.PutAttachment(context.Background(), "test.txt", "text/plain",
+		ioutil.NopCloser(strings.NewReader("content")), couchdb.WithContentLength(7)))
+
+	require.Len(t, *requests, 2)
+	for _, request := range *requests {
+		assert.Equal(t, int64(7), request.contentLength)
+		assert.Empty(t, request.transferEncoding)
+	}
+}
@@ -0,0 +1,41 @@
+package couchdb
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+const methodCopy = "COPY"
+
+// CopyTo copies the document including its attachments to the provided destination id on the server side.
+// If the destination already exists, its current revision has to be provided. The source revision is used,
+// if it's set. The returned document holds the destination id and revision.
+func (d *Document) CopyTo(ctx context.Context, destinationID, destinationRevision string) (*Document, error) {
+	if d.id == "" || destinationID == "" {
+		return nil, ErrMissingID
+	}
+
+	path := d.path()
+	if d.revision != "" {
+		path = withQuery(path, url.Values{"rev": {d.revision}})
+	}
+
+	destination := escapeID(destinationID)
+	if destinationRevision != "" {
+		destination = withQuery(destination, url.Values{"rev": {destinationRevision}})
+	}
+	header := http.Header{}
+	header.Set("Destination", destination)
+
+	r := struct {
+		OK       bool   `json:"ok"`
+		ID       string `json:"id"`
+		Revision string `json:"rev"`
+	}{}
+	if err := d.database.requestJSON(ctx, methodCopy, path, header, nil, &r); err != nil {
+		return nil, err
+	}
+
+	return NewDocument(d.database, r.ID, r.Revision), nil
+}
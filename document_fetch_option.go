@@ -0,0 +1,85 @@
+package couchdb
+
+import (
+	"net/url"
+)
+
+// FetchOption defines a function that can modify the query parameters of a document fetch request.
+type FetchOption func(url.Values) error
+
+// WithRevision returns an option that fetches the provided revision instead of the winning one.
+func WithRevision(revision string) FetchOption {
+	return func(q url.Values) error {
+		q.Set("rev", revision)
+		return nil
+	}
+}
+
+// WithAttachments returns an option that includes the attachment data inline as base64. By default, only
+// the attachment stubs are returned.
+func WithAttachments() FetchOption {
+	return func(q url.Values) error {
+		q.Set("attachments", "true")
+		return nil
+	}
+}
+
+// WithAttachmentEncodingInfo returns an option that includes the encoding infos of compressed attachments.
+func WithAttachmentEncodingInfo() FetchOption {
+	return func(q url.Values) error {
+		q.Set("att_encoding_info", "true")
+		return nil
+	}
+}
+
+// WithAttachmentsSince returns an option that only includes the data of attachments that changed since
+// the provided revisions. The other attachments are returned as stubs.
+func WithAttachmentsSince(revisions ...string) FetchOption {
+	return func(q url.Values) error {
+		return setJSONQuery(q, "atts_since", revisions)
+	}
+}
+
+// WithConflicts returns an option that includes the conflicting revisions in the `_conflicts` field.
+func WithConflicts() FetchOption {
+	return func(q url.Values) error {
+		q.Set("conflicts", "true")
+		return nil
+	}
+}
+
+// WithDeletedConflicts returns an option that includes the deleted conflicting revisions in the
+// `_deleted_conflicts` field.
+func WithDeletedConflicts() FetchOption {
+	return func(q url.Values) error {
+		q.Set("deleted_conflicts", "true")
+		return nil
+	}
+}
+
+// WithRevisions returns an option that includes the revision history in the `_revisions` field.
+func WithRevisions() FetchOption {
+	return func(q url.Values) error {
+		q.Set("revs", "true")
+		return nil
+	}
+}
+
+// WithRevisionsInfo returns an option that includes the revision history with the availability of each
+// revision in the `_revs_info` field.
+func WithRevisionsInfo() FetchOption {
+	return func(q url.Values) error {
+		q.Set("revs_info", "true")
+		return nil
+	}
+}
+
+func fetchQuery(options []FetchOption) (url.Values, error) {
+	query := url.Values{}
+	for _, o := range options {
+		if err := o(query); err != nil {
+			return nil, err
+		}
+	}
+	return query, nil
+}
@@ -0,0 +1,387 @@
+package couchdb
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+// Various errors.
+var (
+	ErrDigestMismatch = errors.New("digest mismatch")
+	ErrLengthMismatch = errors.New("length mismatch")
+)
+
+// MultipartAttachment defines an attachment that is streamed within a multipart request. The length
+// is required by couchdb.
+type MultipartAttachment struct {
+	Name        string
+	ContentType string
+	Length      int64
+	Reader      io.Reader
+}
+
+// StoreMultipart saves the document together with the provided attachments in a single `multipart/related`
+// request. The attachments are streamed from their readers. Afterwards, the digests of the stored attachments
+// are compared to the digests of the sent bytes. Existing attachments, that are not listed as stubs in the
+// data, are removed.
+func (d *Document) StoreMultipart(ctx context.Context, data interface{}, attachments ...MultipartAttachment) error {
+	if d.id == "" {
+		return ErrMissingID
+	}
+
+	// couchdb expects the parts in the order of the `_attachments` object, which is encoded with sorted keys.
+	attachments = append([]MultipartAttachment{}, attachments...)
+	sort.Slice(attachments, func(i, j int) bool { return attachments[i].Name < attachments[j].Name })
+
+	document, err := d.multipartDocument(data, attachments)
+	if err != nil {
+		return err
+	}
+
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+	counter := &countingWriter{}
+	if err := writeMultipart(counter, boundary, document, attachments, func(index int, _ io.Writer) error {
+		counter.n += attachments[index].Length
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	digests := make([]hash.Hash, len(attachments))
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(writeMultipart(writer, boundary, document, attachments, func(index int, w io.Writer) error {
+			digests[index] = md5.New()
+			n, err := io.Copy(w, io.TeeReader(attachments[index].Reader, digests[index]))
+			if err != nil {
+				return fmt.Errorf("attachment %s: %w", attachments[index].Name, err)
+			}
+			if n != attachments[index].Length {
+				return fmt.Errorf("attachment %s: %w: read %d of %d bytes", attachments[index].Name, ErrLengthMismatch, n, attachments[index].Length)
+			}
+			return nil
+		}))
+	}()
+	defer reader.Close()
+
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+	header.Set("Content-Type", mime.FormatMediaType("multipart/related", map[string]string{"boundary": boundary}))
+	header.Set("Content-Length", strconv.FormatInt(counter.n, 10))
+	if d.revision != "" {
+		header.Set("If-Match", d.revision)
+	}
+
+	statusCode, _, responseReader, err := d.database.request(ctx, http.MethodPut, d.path(), header, reader)
+	if err != nil {
+		return err
+	}
+	if err := d.updateRevision(statusCode, responseReader); err != nil {
+		return err
+	}
+
+	return d.verifyDigests(ctx, attachments, digests)
+}
+
+// FetchMultipart loads the document together with all attachments as a `multipart/related` stream. The
+// document is decoded into data, the attachments can be read from the returned reader, which has to be
+// closed by the caller. Using `WithAttachmentsSince`, only the changed attachments are streamed.
+func (d *Document) FetchMultipart(ctx context.Context, data interface{}, options ...FetchOption) (*MultipartReader, error) {
+	if d.id == "" {
+		return nil, ErrMissingID
+	}
+
+	query, err := fetchQuery(append([]FetchOption{WithAttachments()}, options...))
+	if err != nil {
+		return nil, err
+	}
+
+	header := http.Header{}
+	header.Set("Accept", "multipart/related")
+
+	statusCode, responseHeader, responseReader, err := d.database.request(ctx, http.MethodGet, withQuery(d.path(), query), header, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkJSONError(statusCode, responseReader); err != nil {
+		responseReader.Close()
+		return nil, err
+	}
+	if etag := responseHeader.Get("ETag"); etag != "" {
+		d.revision = strings.Trim(etag, `"`)
+	}
+
+	mr, err := newMultipartReader(d.database.client.codec, responseHeader.Get("Content-Type"), responseReader, data)
+	if err != nil {
+		responseReader.Close()
+		return nil, err
+	}
+	return mr, nil
+}
+
+// multipartDocument returns the encoded document with the `_attachments` field that announces the
+// following attachments.
+func (d *Document) multipartDocument(data interface{}, attachments []MultipartAttachment) ([]byte, error) {
+	codec := d.database.client.codec
+
+	buffer := &bytes.Buffer{}
+	if err := codec.Encode(buffer, data); err != nil {
+		return nil, fmt.Errorf("encode: %w", err)
+	}
+	fields := map[string]json.RawMessage{}
+	if err := codec.Decode(buffer, &fields); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	stubs := map[string]json.RawMessage{}
+	if raw, ok := fields[metaAttachments]; ok {
+		if err := json.Unmarshal(raw, &stubs); err != nil {
+			return nil, fmt.Errorf("json decode %s: %w", metaAttachments, err)
+		}
+	}
+	for _, attachment := range attachments {
+		if attachment.Length < 0 {
+			return nil, fmt.Errorf("attachment %s: %w: negative length", attachment.Name, ErrLengthMismatch)
+		}
+		stub, err := json.Marshal(value.Attachment{
+			ContentType: attachment.ContentType,
+			Length:      attachment.Length,
+			Follows:     true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("json encode: %w", err)
+		}
+		stubs[attachment.Name] = stub
+	}
+	raw, err := json.Marshal(stubs)
+	if err != nil {
+		return nil, fmt.Errorf("json encode: %w", err)
+	}
+	fields[metaAttachments] = raw
+
+	buffer.Reset()
+	if err := codec.Encode(buffer, fields); err != nil {
+		return nil, fmt.Errorf("encode: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// verifyDigests compares the digests of the stored attachments with the provided ones.
+func (d *Document) verifyDigests(ctx context.Context, attachments []MultipartAttachment, digests []hash.Hash) error {
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	r := struct {
+		Attachments map[string]value.Attachment `json:"_attachments"`
+	}{}
+	if err := d.database.requestJSON(ctx, http.MethodGet, withQuery(d.path(), url.Values{"rev": {d.revision}}), nil, nil, &r); err != nil {
+		return err
+	}
+
+	for index, attachment := range attachments {
+		expected := "md5-" + base64.StdEncoding.EncodeToString(digests[index].Sum(nil))
+		if actual := r.Attachments[attachment.Name].Digest; actual != expected {
+			return fmt.Errorf("attachment %s: %w: stored %s, sent %s", attachment.Name, ErrDigestMismatch, actual, expected)
+		}
+	}
+	return nil
+}
+
+func writeMultipart(
+	w io.Writer,
+	boundary string,
+	document []byte,
+	attachments []MultipartAttachment,
+	writeBody func(int, io.Writer) error,
+) error {
+	mw := multipart.NewWriter(w)
+	if err := mw.SetBoundary(boundary); err != nil {
+		return fmt.Errorf("set boundary: %w", err)
+	}
+
+	part, err := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {"application/json"}})
+	if err != nil {
+		return fmt.Errorf("create part: %w", err)
+	}
+	if _, err := part.Write(document); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+
+	for index, attachment := range attachments {
+		part, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":        {attachment.ContentType},
+			"Content-Disposition": {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Name})},
+		})
+		if err != nil {
+			return fmt.Errorf("create part: %w", err)
+		}
+		if err := writeBody(index, part); err != nil {
+			return err
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return fmt.Errorf("close: %w", err)
+	}
+	return nil
+}
+
+// MultipartReader implements a reader for the attachments of a document that has been fetched
+// as a `multipart/related` stream.
+type MultipartReader struct {
+	body    io.Closer
+	reader  *multipart.Reader
+	stubs   map[string]value.Attachment
+	current *AttachmentPart
+}
+
+func newMultipartReader(codec Codec, contentType string, body io.ReadCloser, data interface{}) (*MultipartReader, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("parse content type [%s]: %w", contentType, err)
+	}
+
+	mr := &MultipartReader{body: body}
+
+	// Documents without attachments are returned as plain json.
+	documentReader := io.Reader(body)
+	if mediaType == "multipart/related" {
+		mr.reader = multipart.NewReader(body, params["boundary"])
+		part, err := mr.reader.NextPart()
+		if err != nil {
+			return nil, fmt.Errorf("next part: %w", err)
+		}
+		documentReader = part
+	}
+
+	document, err := io.ReadAll(documentReader)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	if err := codec.Decode(bytes.NewReader(document), data); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+	r := struct {
+		Attachments map[string]value.Attachment `json:"_attachments"`
+	}{}
+	if err := json.Unmarshal(document, &r); err != nil {
+		return nil, fmt.Errorf("json decode: %w", err)
+	}
+	mr.stubs = r.Attachments
+
+	return mr, nil
+}
+
+// Next returns the next attachment. If all attachments have been read, `io.EOF` is returned.
+func (mr *MultipartReader) Next() (*AttachmentPart, error) {
+	if mr.current != nil {
+		if _, err := io.Copy(io.Discard, mr.current); err != nil {
+			return nil, err
+		}
+	}
+	if mr.reader == nil {
+		return nil, io.EOF
+	}
+
+	part, err := mr.reader.NextPart()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("next part: %w", err)
+	}
+
+	name := part.FileName()
+	stub := mr.stubs[name]
+	mr.current = &AttachmentPart{
+		Name:            name,
+		ContentType:     stub.ContentType,
+		ContentEncoding: part.Header.Get("Content-Encoding"),
+		Length:          stub.Length,
+		Digest:          stub.Digest,
+		part:            part,
+		digest:          md5.New(),
+	}
+	if contentType := part.Header.Get("Content-Type"); contentType != "" {
+		mr.current.ContentType = contentType
+	}
+	return mr.current, nil
+}
+
+// Close closes the underlying response body.
+func (mr *MultipartReader) Close() error {
+	return mr.body.Close()
+}
+
+// AttachmentPart implements a reader for a single attachment of a `MultipartReader`. When the end of the
+// attachment is reached, the md5 digest of the read bytes is compared to the attachment's digest and
+// `ErrDigestMismatch` is returned if they differ. Encoded attachments are not verified.
+type AttachmentPart struct {
+	Name            string
+	ContentType     string
+	ContentEncoding string
+	Length          int64
+	Digest          string
+
+	part   io.Reader
+	digest hash.Hash
+	err    error
+}
+
+// Read reads the attachment data.
+func (ap *AttachmentPart) Read(p []byte) (int, error) {
+	if ap.err != nil {
+		return 0, ap.err
+	}
+
+	n, err := ap.part.Read(p)
+	ap.digest.Write(p[:n])
+	if errors.Is(err, io.EOF) {
+		if verifyErr := ap.verify(); verifyErr != nil {
+			ap.err = verifyErr
+			return n, verifyErr
+		}
+	}
+	if err != nil {
+		ap.err = err
+	}
+	return n, err
+}
+
+func (ap *AttachmentPart) verify() error {
+	if ap.ContentEncoding != "" || ap.Digest == "" || !strings.HasPrefix(ap.Digest, "md5-") {
+		return nil
+	}
+	actual := "md5-" + base64.StdEncoding.EncodeToString(ap.digest.Sum(nil))
+	if actual != ap.Digest {
+		return fmt.Errorf("attachment %s: %w: expected %s, received %s", ap.Name, ErrDigestMismatch, ap.Digest, actual)
+	}
+	return nil
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	cw.n += int64(len(p))
+	return len(p), nil
+}
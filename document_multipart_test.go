@@ -0,0 +1,96 @@
+package couchdb_test
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+)
+
+func TestDocumentMultipart(t *testing.T) {
+	e := setUpTestEnvironment(t)
+	defer e.tearDown()
+
+	db := couchdb.NewDatabase(e.client, "test")
+	require.NoError(t, db.Create(e.ctx))
+	defer db.Delete(e.ctx)
+
+	t.Run("Store", func(t *testing.T) {
+		document := couchdb.NewDocument(db, "store", "")
+
+		require.NoError(t, document.StoreMultipart(e.ctx, map[string]interface{}{"test": "value"},
+			couchdb.MultipartAttachment{Name: "a.txt", ContentType: "text/plain", Length: 5, Reader: strings.NewReader("hello")},
+			couchdb.MultipartAttachment{Name: "b.bin", ContentType: "application/octet-stream", Length: 3, Reader: strings.NewReader("xyz")},
+		))
+
+		assert.Regexp(t, `^1\-[0-9a-f]+$`, document.Revision())
+
+		reader, info, err := document.Attachment(e.ctx, "b.bin")
+		require.NoError(t, err)
+		defer reader.Close()
+
+		data, err := ioutil.ReadAll(reader)
+		require.NoError(t, err)
+
+		assert.Equal(t, "xyz", string(data))
+		assert.Equal(t, "application/octet-stream", info.ContentType)
+	})
+
+	t.Run("StoreWithLengthMismatch", func(t *testing.T) {
+		document := couchdb.NewDocument(db, "mismatch", "")
+
+		err := document.StoreMultipart(e.ctx, map[string]interface{}{"test": "value"},
+			couchdb.MultipartAttachment{Name: "a.txt", ContentType: "text/plain", Length: 10, Reader: strings.NewReader("hello")})
+		assert.Error(t, err)
+	})
+
+	t.Run("Fetch", func(t *testing.T) {
+		document := couchdb.NewDocument(db, "fetch", "")
+		require.NoError(t, document.StoreMultipart(e.ctx, map[string]interface{}{"test": "value"},
+			couchdb.MultipartAttachment{Name: "a.txt", ContentType: "text/plain", Length: 5, Reader: strings.NewReader("hello")},
+			couchdb.MultipartAttachment{Name: "b.bin", ContentType: "application/octet-stream", Length: 3, Reader: strings.NewReader("xyz")},
+		))
+
+		data := map[string]interface{}{}
+		reader, err := couchdb.NewDocument(db, "fetch", "").FetchMultipart(e.ctx, &data)
+		require.NoError(t, err)
+		defer reader.Close()
+
+		assert.Equal(t, "value", data["test"])
+
+		attachments := map[string]string{}
+		for {
+			part, err := reader.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+
+			content, err := ioutil.ReadAll(part)
+			require.NoError(t, err)
+			attachments[part.Name] = string(content)
+		}
+
+		assert.Equal(t, map[string]string{"a.txt": "hello", "b.bin": "xyz"}, attachments)
+	})
+
+	t.Run("FetchWithoutAttachments", func(t *testing.T) {
+		document := couchdb.NewDocument(db, "plain", "")
+		require.NoError(t, document.Store(e.ctx, map[string]interface{}{"test": "value"}))
+
+		data := map[string]interface{}{}
+		reader, err := couchdb.NewDocument(db, "plain", "").FetchMultipart(e.ctx, &data)
+		require.NoError(t, err)
+		defer reader.Close()
+
+		assert.Equal(t, "value", data["test"])
+
+		_, err = reader.Next()
+		assert.Equal(t, io.EOF, err)
+	})
+}
@@ -1,6 +1,7 @@
 package couchdb_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -89,4 +90,112 @@ func TestDocument(t *testing.T) {
 			}, data)
 		})
 	})
+
+	t.Run("FetchAttachments", func(t *testing.T) {
+		d := couchdb.NewDocument(db, "", "")
+		require.NoError(t, d.Store(e.ctx, map[string]interface{}{"test": "value"}))
+		require.NoError(t, d.PutAttachment(e.ctx, "a.txt", "text/plain", strings.NewReader("hello")))
+		firstRevision := d.Revision()
+		require.NoError(t, d.PutAttachment(e.ctx, "b.txt", "text/plain", strings.NewReader("world")))
+
+		t.Run("Stubs", func(t *testing.T) {
+			value := testEmbeddedValue{}
+			require.NoError(t, couchdb.NewDocument(db, d.ID(), "").Fetch(e.ctx, &value))
+
+			require.Len(t, value.Attachments, 2)
+			assert.True(t, value.Attachments["a.txt"].Stub)
+			assert.Equal(t, "text/plain", value.Attachments["a.txt"].ContentType)
+			assert.Equal(t, int64(5), value.Attachments["a.txt"].Length)
+			assert.Equal(t, uint(2), value.Attachments["a.txt"].RevPos)
+			assert.Nil(t, value.Attachments["a.txt"].Data)
+		})
+
+		t.Run("Inline", func(t *testing.T) {
+			value := testEmbeddedValue{}
+			require.NoError(t, couchdb.NewDocument(db, d.ID(), "").Fetch(e.ctx, &value, couchdb.WithAttachments()))
+
+			assert.False(t, value.Attachments["a.txt"].Stub)
+			assert.Equal(t, []byte("hello"), value.Attachments["a.txt"].Data)
+			assert.Equal(t, []byte("world"), value.Attachments["b.txt"].Data)
+		})
+
+		t.Run("Since", func(t *testing.T) {
+			value := testEmbeddedValue{}
+			require.NoError(t, couchdb.NewDocument(db, d.ID(), "").Fetch(e.ctx, &value,
+				couchdb.WithAttachments(), couchdb.WithAttachmentsSince(firstRevision)))
+
+			assert.True(t, value.Attachments["a.txt"].Stub)
+			assert.Equal(t, []byte("world"), value.Attachments["b.txt"].Data)
+		})
+
+		t.Run("EncodingInfo", func(t *testing.T) {
+			value := testEmbeddedValue{}
+			require.NoError(t, couchdb.NewDocument(db, d.ID(), "").Fetch(e.ctx, &value, couchdb.WithAttachmentEncodingInfo()))
+
+			assert.Equal(t, "gzip", value.Attachments["a.txt"].Encoding)
+			assert.NotZero(t, value.Attachments["a.txt"].EncodedLength)
+		})
+	})
+
+	t.Run("CopyTo", func(t *testing.T) {
+		t.Run("NewDestination", func(t *testing.T) {
+			source := couchdb.NewDocument(db, "", "")
+			require.NoError(t, source.Store(e.ctx, map[string]interface{}{"test": "value"}))
+			require.NoError(t, source.PutAttachment(e.ctx, "a.txt", "text/plain", strings.NewReader("hello")))
+
+			destination, err := source.CopyTo(e.ctx, "copy/new", "")
+			require.NoError(t, err)
+
+			assert.Equal(t, "copy/new", destination.ID())
+			assert.Regexp(t, `^1\-[0-9a-f]+$`, destination.Revision())
+
+			data := map[string]interface{}{}
+			require.NoError(t, destination.Fetch(e.ctx, &data))
+			assert.Equal(t, "value", data["test"])
+
+			_, info, err := destination.Attachment(e.ctx, "a.txt")
+			require.NoError(t, err)
+			assert.Equal(t, int64(5), info.Length)
+		})
+
+		t.Run("ExistingDestination", func(t *testing.T) {
+			source := couchdb.NewDocument(db, "", "")
+			require.NoError(t, source.Store(e.ctx, map[string]interface{}{"test": "value"}))
+			target := couchdb.NewDocument(db, "copy-existing", "")
+			require.NoError(t, target.Store(e.ctx, map[string]interface{}{"test": "old value"}))
+
+			_, err := source.CopyTo(e.ctx, target.ID(), "")
+			assert.ErrorIs(t, err, couchdb.ErrConflict)
+
+			destination, err := source.CopyTo(e.ctx, target.ID(), target.Revision())
+			require.NoError(t, err)
+			assert.Regexp(t, `^2\-[0-9a-f]+$`, destination.Revision())
+		})
+
+		t.Run("MissingSource", func(t *testing.T) {
+			_, err := couchdb.NewDocument(db, "missing", "").CopyTo(e.ctx, "copy-missing", "")
+			assert.ErrorIs(t, err, couchdb.ErrNotFound)
+		})
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		t.Run("WithRevision", func(t *testing.T) {
+			document := couchdb.NewDocument(db, "", "")
+			require.NoError(t, document.Store(e.ctx, map[string]interface{}{"test": "value"}))
+
+			require.NoError(t, document.Delete(e.ctx))
+
+			assert.Regexp(t, `^2\-[0-9a-f]+$`, document.Revision())
+
+			err := couchdb.NewDocument(db, document.ID(), "").Fetch(e.ctx, &map[string]interface{}{})
+			assert.ErrorIs(t, err, couchdb.ErrNotFound)
+		})
+
+		t.Run("WithoutRevision", func(t *testing.T) {
+			document := couchdb.NewDocument(db, "test", "")
+
+			err := document.Delete(e.ctx)
+			assert.ErrorIs(t, err, couchdb.ErrMissingRevision)
+		})
+	})
 }
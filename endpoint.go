@@ -0,0 +1,187 @@
+package couchdb
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EndpointSelection defines the strategy that is used to pick an endpoint for a request.
+type EndpointSelection int
+
+// Various endpoint selection strategies.
+const (
+	// RoundRobin picks the healthy endpoints in turn.
+	RoundRobin EndpointSelection = iota
+	// LeastLatency picks the healthy endpoint with the lowest average latency.
+	LeastLatency
+)
+
+const (
+	defaultFailureThreshold = 3
+	defaultProbeInterval    = 5 * time.Second
+	latencyWeight           = 0.2
+)
+
+type endpoint struct {
+	baseURL string
+
+	mutex    sync.Mutex
+	healthy  bool
+	failures int
+	latency  time.Duration
+}
+
+func (e *endpoint) isHealthy() bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.healthy
+}
+
+func (e *endpoint) averageLatency() time.Duration {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.latency
+}
+
+type endpointPool struct {
+	endpoints        []*endpoint
+	selection        EndpointSelection
+	failureThreshold int
+	probeInterval    time.Duration
+	probe            func(context.Context, *endpoint) bool
+
+	counter uint64
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+func newEndpointPool(
+	baseURLs []string,
+	selection EndpointSelection,
+	failureThreshold int,
+	probeInterval time.Duration,
+	probe func(context.Context, *endpoint) bool,
+) *endpointPool {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &endpointPool{
+		selection:        selection,
+		failureThreshold: failureThreshold,
+		probeInterval:    probeInterval,
+		probe:            probe,
+		ctx:              ctx,
+		cancel:           cancel,
+	}
+	for _, baseURL := range baseURLs {
+		p.endpoints = append(p.endpoints, &endpoint{baseURL: baseURL, healthy: true})
+	}
+	return p
+}
+
+// candidates returns the endpoints in the order they should be tried. If no endpoint is healthy,
+// all endpoints are returned, so requests are still attempted while the probes are pending.
+func (p *endpointPool) candidates() []*endpoint {
+	offset := int(atomic.AddUint64(&p.counter, 1) - 1)
+
+	healthy := make([]*endpoint, 0, len(p.endpoints))
+	for index := range p.endpoints {
+		e := p.endpoints[(offset+index)%len(p.endpoints)]
+		if e.isHealthy() {
+			healthy = append(healthy, e)
+		}
+	}
+	if len(healthy) == 0 {
+		for index := range p.endpoints {
+			healthy = append(healthy, p.endpoints[(offset+index)%len(p.endpoints)])
+		}
+		return healthy
+	}
+
+	if p.selection == LeastLatency {
+		latencies := make(map[*endpoint]time.Duration, len(healthy))
+		for _, e := range healthy {
+			latencies[e] = e.averageLatency()
+		}
+		sort.SliceStable(healthy, func(i, j int) bool { return latencies[healthy[i]] < latencies[healthy[j]] })
+	}
+
+	return healthy
+}
+
+func (p *endpointPool) succeeded(e *endpoint, latency time.Duration) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.failures = 0
+	if e.latency == 0 {
+		e.latency = latency
+	} else {
+		e.latency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(e.latency))
+	}
+}
+
+func (p *endpointPool) failed(e *endpoint) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.failures++
+	if e.healthy && e.failures >= p.failureThreshold {
+		e.healthy = false
+		go p.watch(e)
+	}
+}
+
+// watch probes the provided unhealthy endpoint until it recovers or the pool is closed.
+func (p *endpointPool) watch(e *endpoint) {
+	ticker := time.NewTicker(p.probeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			if !p.probe(p.ctx, e) {
+				continue
+			}
+			e.mutex.Lock()
+			e.healthy = true
+			e.failures = 0
+			e.latency = 0
+			e.mutex.Unlock()
+			return
+		}
+	}
+}
+
+func (p *endpointPool) close() {
+	p.cancel()
+}
+
+func isUnavailable(statusCode int) bool {
+	switch statusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isIdempotent returns true if a request with the provided method can be repeated without side effects.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// isDialError returns true if the provided error occurred while connecting, so no request has been sent.
+func isDialError(err error) bool {
+	opErr := (*net.OpError)(nil)
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,145 @@
+package couchdb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+)
+
+func TestEndpoints(t *testing.T) {
+	t.Run("RoundRobin", func(t *testing.T) {
+		client, httpClient := newStubClient(t, couchdb.WithEndpoints("http://b"))
+		httpClient.handle("http://a/", jsonResponse(200, `{"couchdb":"Welcome"}`))
+		httpClient.handle("http://b/", jsonResponse(200, `{"couchdb":"Welcome"}`))
+
+		for index := 0; index < 4; index++ {
+			_, err := client.InstanceInfo(context.Background())
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, []string{"http://a/", "http://b/", "http://a/", "http://b/"}, httpClient.urls())
+	})
+
+	t.Run("Failover", func(t *testing.T) {
+		client, httpClient := newStubClient(t,
+			couchdb.WithEndpoints("http://b"),
+			couchdb.WithFailureThreshold(2))
+		httpClient.handle("http://a/", unreachable())
+		httpClient.handle("http://b/", jsonResponse(200, `{"couchdb":"Welcome"}`))
+
+		for index := 0; index < 4; index++ {
+			ii, err := client.InstanceInfo(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, "Welcome", ii.CouchDB)
+		}
+
+		assert.Equal(t, []string{
+			"http://a/", "http://b/",
+			"http://b/",
+			"http://a/", "http://b/",
+			"http://b/",
+		}, httpClient.urls())
+	})
+
+	t.Run("FailoverOnUnavailable", func(t *testing.T) {
+		client, httpClient := newStubClient(t, couchdb.WithEndpoints("http://b"))
+		httpClient.handle("http://a/", jsonResponse(503, `{"error":"unavailable"}`))
+		httpClient.handle("http://b/", jsonResponse(200, `{"couchdb":"Welcome"}`))
+
+		ii, err := client.InstanceInfo(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "Welcome", ii.CouchDB)
+	})
+
+	t.Run("FailoverOfUnsentWrite", func(t *testing.T) {
+		client, httpClient := newStubClient(t, couchdb.WithEndpoints("http://b"))
+		httpClient.handle("http://a/", undialable())
+		httpClient.handle("http://b/", jsonResponse(201, `{"ok":true,"id":"test","rev":"1-a"}`))
+
+		document := couchdb.NewDocument(couchdb.NewDatabase(client, "test"), "", "")
+		require.NoError(t, document.Store(context.Background(), map[string]interface{}{"test": "value"}))
+		assert.Equal(t, []string{"http://a/test", "http://b/test"}, httpClient.urls())
+	})
+
+	t.Run("NoFailoverOfPossiblySentWrite", func(t *testing.T) {
+		client, httpClient := newStubClient(t, couchdb.WithEndpoints("http://b"))
+		httpClient.handle("http://a/", unreachable())
+		httpClient.handle("http://b/", jsonResponse(201, `{"ok":true,"id":"test","rev":"1-a"}`))
+
+		document := couchdb.NewDocument(couchdb.NewDatabase(client, "test"), "", "")
+		err := document.Store(context.Background(), map[string]interface{}{"test": "value"})
+		assert.ErrorIs(t, err, errStubUnreachable)
+		assert.Equal(t, []string{"http://a/test"}, httpClient.urls())
+	})
+
+	t.Run("AllUnreachable", func(t *testing.T) {
+		client, httpClient := newStubClient(t, couchdb.WithEndpoints("http://b"))
+		httpClient.handle("http://a/", unreachable())
+		httpClient.handle("http://b/", unreachable())
+
+		_, err := client.InstanceInfo(context.Background())
+		assert.ErrorIs(t, err, errStubUnreachable)
+	})
+
+	t.Run("Recovery", func(t *testing.T) {
+		client, httpClient := newStubClient(t,
+			couchdb.WithEndpoints("http://b"),
+			couchdb.WithFailureThreshold(1),
+			couchdb.WithProbeInterval(10*time.Millisecond))
+		httpClient.handle("http://a/", unreachable())
+		httpClient.handle("http://b/", jsonResponse(200, `{"couchdb":"Welcome"}`))
+
+		_, err := client.InstanceInfo(context.Background())
+		require.NoError(t, err)
+
+		httpClient.handle("http://a/", jsonResponse(200, `{"couchdb":"Welcome"}`))
+		require.Eventually(t, func() bool {
+			httpClient.reset()
+			for index := 0; index < 2; index++ {
+				_, err := client.InstanceInfo(context.Background())
+				require.NoError(t, err)
+			}
+			return assert.ObjectsAreEqual([]string{"http://a/", "http://b/"}, httpClient.urls()) ||
+				assert.ObjectsAreEqual([]string{"http://b/", "http://a/"}, httpClient.urls())
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("LeastLatency", func(t *testing.T) {
+		client, httpClient := newStubClient(t,
+			couchdb.WithEndpoints("http://b"),
+			couchdb.WithEndpointSelection(couchdb.LeastLatency),
+			couchdb.WithFailureThreshold(1),
+			couchdb.WithProbeInterval(time.Hour))
+		httpClient.handle("http://a/", func(stubRequest) stubResponse {
+			time.Sleep(20 * time.Millisecond)
+			return stubResponse{statusCode: 200, body: `{"couchdb":"Welcome"}`}
+		})
+		httpClient.handle("http://b/", jsonResponse(200, `{"couchdb":"Welcome"}`))
+
+		// The first requests measure the latency of both endpoints.
+		for index := 0; index < 2; index++ {
+			_, err := client.InstanceInfo(context.Background())
+			require.NoError(t, err)
+		}
+
+		httpClient.reset()
+		for index := 0; index < 4; index++ {
+			_, err := client.InstanceInfo(context.Background())
+			require.NoError(t, err)
+		}
+		assert.Equal(t, []string{"http://b/", "http://b/", "http://b/", "http://b/"}, httpClient.urls())
+
+		httpClient.handle("http://b/", unreachable())
+		httpClient.reset()
+		for index := 0; index < 3; index++ {
+			_, err := client.InstanceInfo(context.Background())
+			require.NoError(t, err)
+		}
+		assert.Equal(t, []string{"http://b/", "http://a/", "http://a/", "http://a/"}, httpClient.urls())
+	})
+}
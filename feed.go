@@ -0,0 +1,254 @@
+package couchdb
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+// FeedType defines the type of a feed.
+type FeedType string
+
+// Various feed types.
+const (
+	// FeedNormal returns all entries at once.
+	FeedNormal FeedType = "normal"
+	// FeedLongpoll waits until at least one entry is available and returns the entries at once.
+	FeedLongpoll FeedType = "longpoll"
+	// FeedContinuous streams the entries as they occur until the request is cancelled or timed out.
+	FeedContinuous FeedType = "continuous"
+)
+
+// FeedOption defines a function that can modify the query parameters of a feed request.
+type FeedOption func(url.Values) error
+
+// WithFeed returns an option that sets the feed type. It defaults to `FeedNormal`.
+func WithFeed(value FeedType) FeedOption {
+	return func(q url.Values) error {
+		q.Set("feed", string(value))
+		return nil
+	}
+}
+
+// WithSince returns an option that only returns the entries after the provided sequence. The
+// sequence `now` skips all existing entries.
+func WithSince(value value.Seq) FeedOption {
+	return func(q url.Values) error {
+		q.Set("since", value.String())
+		return nil
+	}
+}
+
+// WithTimeout returns an option that sets the time after which a longpoll or continuous feed is closed
+// if no entries occur.
+func WithTimeout(value time.Duration) FeedOption {
+	return func(q url.Values) error {
+		q.Set("timeout", strconv.FormatInt(value.Milliseconds(), 10))
+		return nil
+	}
+}
+
+// WithHeartbeat returns an option that sets the interval in which empty lines are sent to keep a longpoll
+// or continuous feed alive.
+func WithHeartbeat(value time.Duration) FeedOption {
+	return func(q url.Values) error {
+		q.Set("heartbeat", strconv.FormatInt(value.Milliseconds(), 10))
+		return nil
+	}
+}
+
+// WithFeedLimit returns an option that limits the number of returned entries.
+func WithFeedLimit(value uint) FeedOption {
+	return func(q url.Values) error {
+		q.Set("limit", strconv.FormatUint(uint64(value), 10))
+		return nil
+	}
+}
+
+// WithFeedDescending returns an option that returns the entries in descending order.
+func WithFeedDescending() FeedOption {
+	return func(q url.Values) error {
+		q.Set("descending", "true")
+		return nil
+	}
+}
+
+// WithFeedIncludeDocs returns an option that includes the documents in the changes feed entries.
+func WithFeedIncludeDocs() FeedOption {
+	return func(q url.Values) error {
+		q.Set("include_docs", "true")
+		return nil
+	}
+}
+
+// WithStyleAllDocs returns an option that includes all leaf revisions in the changes feed entries
+// instead of just the winning one.
+func WithStyleAllDocs() FeedOption {
+	return func(q url.Values) error {
+		q.Set("style", "all_docs")
+		return nil
+	}
+}
+
+// WithDocIDs returns an option that restricts the changes feed to the provided document ids.
+func WithDocIDs(ids ...string) FeedOption {
+	return func(q url.Values) error {
+		q.Set("filter", "_doc_ids")
+		return setJSONQuery(q, "doc_ids", ids)
+	}
+}
+
+func feedQuery(options []FeedOption) (url.Values, error) {
+	query := url.Values{}
+	for _, o := range options {
+		if err := o(query); err != nil {
+			return nil, err
+		}
+	}
+	return query, nil
+}
+
+type requestFunc func(
+	ctx context.Context,
+	method,
+	path string,
+	header http.Header,
+	body,
+	responseBody interface{},
+) error
+
+// openFeed requests the feed at the provided path and returns an iterator over its entries, which are
+// decoded using the provided codec.
+func openFeed[T any](
+	ctx context.Context,
+	codec Codec,
+	request requestFunc,
+	path string,
+	options []FeedOption,
+) (*feed[T], error) {
+	query, err := feedQuery(options)
+	if err != nil {
+		return nil, err
+	}
+
+	responseReader := io.ReadCloser(nil)
+	if err := request(ctx, http.MethodGet, withQuery(path, query), nil, nil, &responseReader); err != nil {
+		return nil, err
+	}
+
+	return newFeed[T](ctx, codec, FeedType(query.Get("feed")), responseReader)
+}
+
+// feed implements an iterator over the entries of a normal, longpoll or continuous feed.
+type feed[T any] struct {
+	ctx    context.Context
+	codec  Codec
+	body   io.ReadCloser
+	reader *bufio.Reader
+
+	entries []T
+	index   int
+	current T
+	lastSeq value.Seq
+	pending uint64
+	done    bool
+	err     error
+}
+
+func newFeed[T any](ctx context.Context, codec Codec, feedType FeedType, body io.ReadCloser) (*feed[T], error) {
+	f := &feed[T]{ctx: ctx, codec: codec, body: body}
+	if feedType == FeedContinuous {
+		f.reader = bufio.NewReader(body)
+		return f, nil
+	}
+
+	defer body.Close()
+	r := struct {
+		Results []T       `json:"results"`
+		LastSeq value.Seq `json:"last_seq"`
+		Pending uint64    `json:"pending"`
+	}{}
+	if err := codec.Decode(body, &r); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+	f.entries, f.lastSeq, f.pending = r.Results, r.LastSeq, r.Pending
+	return f, nil
+}
+
+func (f *feed[T]) next() bool {
+	if f.done || f.err != nil {
+		return false
+	}
+
+	if f.reader == nil {
+		if f.index >= len(f.entries) {
+			f.done = true
+			return false
+		}
+		f.current = f.entries[f.index]
+		f.index++
+		return true
+	}
+
+	line, err := f.readLine()
+	if err != nil {
+		f.done = true
+		switch {
+		case f.ctx.Err() != nil:
+			f.err = f.ctx.Err()
+		case errors.Is(err, io.EOF):
+		default:
+			f.err = err
+		}
+		return false
+	}
+
+	// The last line of a continuous feed only holds the last sequence.
+	end := struct {
+		LastSeq *value.Seq `json:"last_seq"`
+		Pending uint64     `json:"pending"`
+	}{}
+	if err := f.codec.Decode(bytes.NewReader(line), &end); err != nil {
+		f.done, f.err = true, fmt.Errorf("decode: %w", err)
+		return false
+	}
+	if end.LastSeq != nil {
+		f.lastSeq, f.pending, f.done = *end.LastSeq, end.Pending, true
+		return false
+	}
+
+	var entry T
+	if err := f.codec.Decode(bytes.NewReader(line), &entry); err != nil {
+		f.done, f.err = true, fmt.Errorf("decode: %w", err)
+		return false
+	}
+	f.current = entry
+	return true
+}
+
+// readLine returns the next non-empty line of a continuous feed. Empty lines are sent as heartbeats.
+func (f *feed[T]) readLine() ([]byte, error) {
+	for {
+		line, err := f.reader.ReadBytes('\n')
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			return line, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (f *feed[T]) close() error {
+	return f.body.Close()
+}
@@ -0,0 +1,34 @@
+package couchdb
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+// Find performs the provided mango query.
+func (db *Database) Find(ctx context.Context, query value.FindQuery) (value.FindResult, error) {
+	return db.find(ctx, db.path("_find"), query)
+}
+
+// Explain returns the index and the parameters, that would be used to perform the provided mango query.
+func (db *Database) Explain(ctx context.Context, query value.FindQuery) (value.ExplainResult, error) {
+	return db.explain(ctx, db.path("_explain"), query)
+}
+
+func (db *Database) find(ctx context.Context, path string, query value.FindQuery) (value.FindResult, error) {
+	r := value.FindResult{}
+	if err := db.requestJSON(ctx, http.MethodPost, path, nil, query, &r); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+func (db *Database) explain(ctx context.Context, path string, query value.FindQuery) (value.ExplainResult, error) {
+	r := value.ExplainResult{}
+	if err := db.requestJSON(ctx, http.MethodPost, path, nil, query, &r); err != nil {
+		return r, err
+	}
+	return r, nil
+}
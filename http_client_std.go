@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 )
 
 // HTTPClientStd implements a `HTTPClient` using go's standart library.
@@ -32,6 +33,11 @@ func (c *HTTPClientStd) Request(
 		return 0, nil, nil, fmt.Errorf("new request: %w", err)
 	}
 	request.Header = header
+	if contentLength := header.Get("Content-Length"); contentLength != "" {
+		if request.ContentLength, err = strconv.ParseInt(contentLength, 10, 64); err != nil {
+			return 0, nil, nil, fmt.Errorf("parse content length [%s]: %w", contentLength, err)
+		}
+	}
 
 	response, err := c.client.Do(request)
 	if err != nil {
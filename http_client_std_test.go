@@ -0,0 +1,45 @@
+package couchdb_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+)
+
+type recordedRequest struct {
+	contentLength    int64
+	transferEncoding []string
+}
+
+func newRecordingServer(t *testing.T, responseBody string) (*httptest.Server, *[]recordedRequest) {
+	requests := []recordedRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, recordedRequest{contentLength: r.ContentLength, transferEncoding: r.TransferEncoding})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(responseBody))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestHTTPClientStdContentLength(t *testing.T) {
+	server, requests := newRecordingServer(t, `{"ok":true,"id":"test","rev":"1-a"}`)
+
+	client, err := couchdb.NewClient(server.URL)
+	require.NoError(t, err)
+	defer client.Close()
+
+	document := couchdb.NewDocument(couchdb.NewDatabase(client, "test"), "test", "")
+	require.NoError(t, document.Store(context.Background(), map[string]interface{}{"test": "value"}))
+
+	require.Len(t, *requests, 1)
+	assert.Equal(t, int64(len(`{"test":"value"}`)+1), (*requests)[0].contentLength)
+	assert.Empty(t, (*requests)[0].transferEncoding)
+}
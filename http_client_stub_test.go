@@ -0,0 +1,134 @@
+package couchdb_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+)
+
+var errStubUnreachable = errors.New("unreachable")
+
+type stubRequest struct {
+	method string
+	url    string
+	header http.Header
+	body   string
+}
+
+type stubResponse struct {
+	statusCode int
+	header     http.Header
+	body       string
+	err        error
+}
+
+type stubHTTPClient struct {
+	mutex     sync.Mutex
+	requests  []stubRequest
+	responses map[string]func(stubRequest) stubResponse
+}
+
+func newStubHTTPClient() *stubHTTPClient {
+	return &stubHTTPClient{responses: map[string]func(stubRequest) stubResponse{}}
+}
+
+// newStubClient returns a client for `http://a`, that sends its requests to a new stub http client. The
+// client is closed when the test finished.
+func newStubClient(t *testing.T, options ...couchdb.ClientOption) (*couchdb.Client, *stubHTTPClient) {
+	httpClient := newStubHTTPClient()
+	options = append([]couchdb.ClientOption{couchdb.WithHTTPClient(httpClient)}, options...)
+	client, err := couchdb.NewClient("http://a", options...)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return client, httpClient
+}
+
+// handle registers a response for all requests whose url starts with the provided prefix.
+func (c *stubHTTPClient) handle(prefix string, fn func(stubRequest) stubResponse) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.responses[prefix] = fn
+}
+
+func (c *stubHTTPClient) Request(
+	ctx context.Context,
+	method, url string,
+	header http.Header,
+	body io.Reader,
+) (int, http.Header, io.ReadCloser, error) {
+	r := stubRequest{method: method, url: url, header: header.Clone()}
+	if body != nil {
+		b, err := ioutil.ReadAll(body)
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		r.body = string(b)
+	}
+
+	c.mutex.Lock()
+	c.requests = append(c.requests, r)
+	fn, prefix := (func(stubRequest) stubResponse)(nil), ""
+	for p, f := range c.responses {
+		if strings.HasPrefix(url, p) && len(p) > len(prefix) {
+			fn, prefix = f, p
+		}
+	}
+	c.mutex.Unlock()
+
+	if fn == nil {
+		return http.StatusNotFound, http.Header{}, ioutil.NopCloser(strings.NewReader(`{"error":"not_found","reason":"missing"}`)), nil
+	}
+	response := fn(r)
+	if response.err != nil {
+		return 0, nil, nil, response.err
+	}
+	if response.header == nil {
+		response.header = http.Header{}
+	}
+	return response.statusCode, response.header, ioutil.NopCloser(bytes.NewBufferString(response.body)), nil
+}
+
+func (c *stubHTTPClient) urls() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	urls := []string{}
+	for _, r := range c.requests {
+		urls = append(urls, r.url)
+	}
+	return urls
+}
+
+func (c *stubHTTPClient) reset() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.requests = nil
+}
+
+func jsonResponse(statusCode int, body string) func(stubRequest) stubResponse {
+	return func(stubRequest) stubResponse {
+		return stubResponse{statusCode: statusCode, body: body}
+	}
+}
+
+func unreachable() func(stubRequest) stubResponse {
+	return func(stubRequest) stubResponse {
+		return stubResponse{err: errStubUnreachable}
+	}
+}
+
+func undialable() func(stubRequest) stubResponse {
+	return func(stubRequest) stubResponse {
+		return stubResponse{err: &net.OpError{Op: "dial", Net: "tcp", Err: errStubUnreachable}}
+	}
+}
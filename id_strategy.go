@@ -0,0 +1,85 @@
+package couchdb
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+const typePrefixSeparator = ":"
+
+// IDStrategy defines how the ids of new entities of a `Repository` are generated.
+type IDStrategy[T any] interface {
+	// ID returns the id for the provided value.
+	ID(typeName string, value *T) (string, error)
+
+	// Prefix returns the prefix that all ids of the provided type share or an empty string if
+	// the ids are not prefixed.
+	Prefix(typeName string) string
+}
+
+type uuidStrategy[T any] struct {
+	prefixed bool
+}
+
+// UUIDStrategy returns an id strategy that generates random (version 4) uuids as defined by RFC 4122, e.g.
+// `0b5b8d4e-7a1f-4c3e-9d2a-5f6e7d8c9b0a`.
+func UUIDStrategy[T any]() IDStrategy[T] {
+	return uuidStrategy[T]{}
+}
+
+// TypePrefixedStrategy returns an id strategy that generates random (version 4) uuids that are prefixed
+// with the type name, e.g. `order:0b5b8d4e-...`.
+func TypePrefixedStrategy[T any]() IDStrategy[T] {
+	return uuidStrategy[T]{prefixed: true}
+}
+
+func (s uuidStrategy[T]) ID(typeName string, _ *T) (string, error) {
+	id, err := newUUID()
+	if err != nil {
+		return "", err
+	}
+	return s.Prefix(typeName) + id, nil
+}
+
+func (s uuidStrategy[T]) Prefix(typeName string) string {
+	if !s.prefixed {
+		return ""
+	}
+	return typeName + typePrefixSeparator
+}
+
+type naturalKeyStrategy[T any] struct {
+	key func(*T) string
+}
+
+// NaturalKeyStrategy returns an id strategy that derives the id from the provided key function. The
+// key is prefixed with the type name, e.g. `user:alice`.
+func NaturalKeyStrategy[T any](key func(*T) string) IDStrategy[T] {
+	return naturalKeyStrategy[T]{key: key}
+}
+
+func (s naturalKeyStrategy[T]) ID(typeName string, value *T) (string, error) {
+	key := s.key(value)
+	if key == "" {
+		return "", fmt.Errorf("natural key: %w", ErrMissingID)
+	}
+	return s.Prefix(typeName) + key, nil
+}
+
+func (s naturalKeyStrategy[T]) Prefix(typeName string) string {
+	return typeName + typePrefixSeparator
+}
+
+// newUUID returns a random (version 4) uuid as defined by RFC 4122.
+func newUUID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("uuid: %w", err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant RFC 4122
+
+	h := hex.EncodeToString(b)
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32], nil
+}
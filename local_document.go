@@ -0,0 +1,119 @@
+package couchdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// LocalDocument implements all methods on a couchdb local document. Local documents are not replicated
+// and don't show up in views or the changes feed, which makes them suitable for checkpoints and
+// per-database state.
+type LocalDocument struct {
+	database *Database
+	id       string
+	revision string
+}
+
+// NewLocalDocument returns a new local document. The id can be provided with or without the `_local/`
+// prefix.
+func NewLocalDocument(database *Database, id, revision string) *LocalDocument {
+	return &LocalDocument{
+		database: database,
+		id:       strings.TrimPrefix(id, localPrefix),
+		revision: revision,
+	}
+}
+
+// ID returns the document's id including the `_local/` prefix.
+func (ld *LocalDocument) ID() string {
+	return localPrefix + ld.id
+}
+
+// Revision returns the document's revision.
+func (ld *LocalDocument) Revision() string {
+	return ld.revision
+}
+
+// Store saves the document to the database. The data can also be a `json.RawMessage` or an `io.Reader`
+// that provides the encoded document.
+func (ld *LocalDocument) Store(ctx context.Context, data interface{}) error {
+	if ld.id == "" {
+		return ErrMissingID
+	}
+
+	r := struct {
+		OK       bool   `json:"ok"`
+		ID       string `json:"id"`
+		Revision string `json:"rev"`
+	}{}
+	if err := ld.database.requestJSON(ctx, http.MethodPut, ld.revisedPath(), nil, data, &r); err != nil {
+		return err
+	}
+	if r.OK {
+		ld.revision = r.Revision
+	}
+	return nil
+}
+
+// Fetch loads the document into the provided data. Local documents are served without an ETag, so the
+// revision is taken from the document body.
+func (ld *LocalDocument) Fetch(ctx context.Context, data interface{}) error {
+	if ld.id == "" {
+		return ErrMissingID
+	}
+
+	raw := json.RawMessage{}
+	if err := ld.database.requestJSON(ctx, http.MethodGet, ld.path(), nil, nil, &raw); err != nil {
+		return err
+	}
+
+	meta := struct {
+		Revision string `json:"_rev"`
+	}{}
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return fmt.Errorf("json decode: %w", err)
+	}
+	ld.revision = meta.Revision
+
+	if data == nil {
+		return nil
+	}
+	if err := ld.database.client.codec.Decode(bytes.NewReader(raw), data); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the document from the database.
+func (ld *LocalDocument) Delete(ctx context.Context) error {
+	if ld.id == "" {
+		return ErrMissingID
+	}
+	if ld.revision == "" {
+		return ErrMissingRevision
+	}
+
+	if err := ld.database.requestJSON(ctx, http.MethodDelete, ld.revisedPath(), nil, nil, nil); err != nil {
+		return err
+	}
+	ld.revision = ""
+	return nil
+}
+
+// path returns the path to the document.
+func (ld *LocalDocument) path() string {
+	return documentPath(ld.database.name, ld.ID())
+}
+
+// revisedPath returns the path to the document including the current revision, if present.
+func (ld *LocalDocument) revisedPath() string {
+	if ld.revision == "" {
+		return ld.path()
+	}
+	return withQuery(ld.path(), url.Values{"rev": {ld.revision}})
+}
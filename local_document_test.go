@@ -0,0 +1,93 @@
+package couchdb_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+)
+
+func TestLocalDocument(t *testing.T) {
+	e := setUpTestEnvironment(t)
+	defer e.tearDown()
+
+	db := couchdb.NewDatabase(e.client, "test")
+	require.NoError(t, db.Create(e.ctx))
+	defer db.Delete(e.ctx)
+
+	document := couchdb.NewLocalDocument(db, "checkpoint", "")
+
+	t.Run("Store", func(t *testing.T) {
+		require.NoError(t, document.Store(e.ctx, map[string]interface{}{"seq": "1"}))
+		assert.NotEmpty(t, document.Revision())
+
+		require.NoError(t, document.Store(e.ctx, map[string]interface{}{"seq": "2"}))
+	})
+
+	t.Run("Fetch", func(t *testing.T) {
+		fetched := couchdb.NewLocalDocument(db, "_local/checkpoint", "")
+		data := map[string]interface{}{}
+		require.NoError(t, fetched.Fetch(e.ctx, &data))
+
+		assert.Equal(t, "_local/checkpoint", fetched.ID())
+		assert.Equal(t, document.Revision(), fetched.Revision())
+		assert.Equal(t, "2", data["seq"])
+	})
+
+	t.Run("LocalDocs", func(t *testing.T) {
+		result, err := db.LocalDocs(e.ctx, couchdb.WithIncludeDocs())
+		require.NoError(t, err)
+
+		require.Len(t, result.Rows, 1)
+		assert.Equal(t, "_local/checkpoint", result.Rows[0].ID)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, document.Delete(e.ctx))
+
+		err := couchdb.NewLocalDocument(db, "checkpoint", "").Fetch(e.ctx, nil)
+		assert.ErrorIs(t, err, couchdb.ErrNotFound)
+	})
+
+	t.Run("DeleteWithoutRevision", func(t *testing.T) {
+		err := couchdb.NewLocalDocument(db, "checkpoint", "").Delete(e.ctx)
+		assert.ErrorIs(t, err, couchdb.ErrMissingRevision)
+	})
+}
+
+func TestLocalDocumentStub(t *testing.T) {
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/test/_local/a%2Fb", func(request stubRequest) stubResponse {
+		switch request.method {
+		case http.MethodPut:
+			return stubResponse{statusCode: 201, body: `{"ok":true,"id":"_local/a/b","rev":"0-2"}`}
+		case http.MethodDelete:
+			return stubResponse{statusCode: 200, body: `{"ok":true,"id":"_local/a/b","rev":"0-0"}`}
+		}
+		return stubResponse{statusCode: 200, body: `{"_id":"_local/a/b","_rev":"0-1","test":"value"}`}
+	})
+
+	document := couchdb.NewLocalDocument(couchdb.NewDatabase(client, "test"), "a/b", "")
+
+	data := struct {
+		Test string `json:"test"`
+	}{}
+	require.NoError(t, document.Fetch(context.Background(), &data))
+	assert.Equal(t, "0-1", document.Revision())
+	assert.Equal(t, "value", data.Test)
+
+	require.NoError(t, document.Store(context.Background(), data))
+	assert.Equal(t, "0-2", document.Revision())
+
+	require.NoError(t, document.Delete(context.Background()))
+
+	assert.Equal(t, []string{
+		"http://a/test/_local/a%2Fb",
+		"http://a/test/_local/a%2Fb?rev=0-1",
+		"http://a/test/_local/a%2Fb?rev=0-2",
+	}, httpClient.urls())
+}
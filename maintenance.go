@@ -0,0 +1,162 @@
+package couchdb
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+const (
+	defaultWaitInterval = time.Second
+	defaultStartGrace   = 5 * time.Second
+)
+
+// MaintenanceOption defines a function that can modify the parameters of a maintenance request.
+type MaintenanceOption func(*maintenance)
+
+type maintenance struct {
+	wait       bool
+	interval   time.Duration
+	startGrace time.Duration
+}
+
+// WithWait returns an option that blocks until the triggered task finished. The task's state is polled
+// in the provided interval. Since the server starts the task asynchronously, the polling first waits until
+// the task has been seen running. If that doesn't happen within the start grace period, the task is assumed
+// to have finished before the first poll.
+func WithWait(interval time.Duration) MaintenanceOption {
+	return func(m *maintenance) {
+		m.wait = true
+		if interval > 0 {
+			m.interval = interval
+		}
+	}
+}
+
+// WithStartGrace returns an option that sets the period in which a waiting maintenance call or `WaitForTask`
+// expects the task to show up. It defaults to 5 seconds.
+func WithStartGrace(value time.Duration) MaintenanceOption {
+	return func(m *maintenance) {
+		if value > 0 {
+			m.startGrace = value
+		}
+	}
+}
+
+// Compact triggers the compaction of the database. With the `WithWait` option, it blocks until the database
+// info reported a running compaction and doesn't report it anymore.
+func (db *Database) Compact(ctx context.Context, options ...MaintenanceOption) error {
+	m := maintenanceOf(options)
+
+	if err := db.maintain(ctx, db.path("_compact")); err != nil {
+		return err
+	}
+	if !m.wait {
+		return nil
+	}
+
+	return m.waitFor(ctx, func(ctx context.Context) (bool, error) {
+		info, err := db.Info(ctx)
+		if err != nil {
+			return false, err
+		}
+		return info.CompactRunning, nil
+	})
+}
+
+// CompactViews triggers the compaction of the views of the provided design document. With the `WithWait`
+// option, it blocks until the view compaction showed up in the active tasks and disappeared again.
+func (db *Database) CompactViews(ctx context.Context, designDocument string, options ...MaintenanceOption) error {
+	m := maintenanceOf(options)
+
+	designDocument = strings.TrimPrefix(designDocument, designPrefix)
+	if err := db.maintain(ctx, db.path("_compact", escapePathSegment(designDocument))); err != nil {
+		return err
+	}
+	if !m.wait {
+		return nil
+	}
+
+	return m.waitFor(ctx, db.client.taskRunning(MatchViewCompaction(db.name, designDocument)))
+}
+
+// ViewCleanup triggers the removal of view indexes, that are not used by any design document anymore. The
+// server doesn't report the cleanup itself as active task, so it runs in the background after the call
+// returned. With the `WithWait` option, it blocks until no indexer or view compaction of the database, which
+// would hold on to the index files, is running anymore.
+func (db *Database) ViewCleanup(ctx context.Context, options ...MaintenanceOption) error {
+	m := maintenanceOf(options)
+
+	if err := db.maintain(ctx, db.path("_view_cleanup")); err != nil {
+		return err
+	}
+	if !m.wait {
+		return nil
+	}
+
+	return m.waitFor(ctx, db.client.taskRunning(func(task value.ActiveTask) bool {
+		return (task.Type == value.ActiveTaskIndexer || task.Type == value.ActiveTaskViewCompaction) &&
+			taskDatabase(task.Database) == db.name
+	}))
+}
+
+// EnsureFullCommit commits all recent changes to disk. The request is synchronous, so all changes are
+// committed once the call returned and no wait mode is needed.
+func (db *Database) EnsureFullCommit(ctx context.Context) error {
+	return db.maintain(ctx, db.path("_ensure_full_commit"))
+}
+
+// maintain posts an empty request to the provided maintenance endpoint.
+func (db *Database) maintain(ctx context.Context, path string) error {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+
+	r := value.Status{}
+	if err := db.requestJSON(ctx, http.MethodPost, path, header, nil, &r); err != nil {
+		return err
+	}
+	if !r.OK {
+		return fmt.Errorf("unknown error %s: %s", r.Error, r.Reason)
+	}
+	return nil
+}
+
+// waitFor polls the provided function in the wait interval until the observed task has been seen running
+// and finished. If the task isn't seen within the start grace period, it returns as well.
+func (m maintenance) waitFor(ctx context.Context, running func(context.Context) (bool, error)) error {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	deadline := time.Now().Add(m.startGrace)
+	started := false
+	for {
+		r, err := running(ctx)
+		if err != nil {
+			return err
+		}
+		switch {
+		case r:
+			started = true
+		case started || !time.Now().Before(deadline):
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func maintenanceOf(options []MaintenanceOption) maintenance {
+	m := maintenance{interval: defaultWaitInterval, startGrace: defaultStartGrace}
+	for _, o := range options {
+		o(&m)
+	}
+	return m
+}
@@ -0,0 +1,150 @@
+package couchdb_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+)
+
+func TestMaintenance(t *testing.T) {
+	e := setUpTestEnvironment(t)
+	defer e.tearDown()
+
+	db := couchdb.NewDatabase(e.client, "test")
+	require.NoError(t, db.Create(e.ctx))
+	defer db.Delete(e.ctx)
+
+	require.NoError(t, couchdb.NewDocument(db, "_design/test", "").Store(e.ctx, map[string]interface{}{
+		"views": map[string]interface{}{
+			"test": map[string]interface{}{"map": "function (doc) { emit(doc._id, null); }"},
+		},
+	}))
+
+	t.Run("Compact", func(t *testing.T) {
+		require.NoError(t, db.Compact(e.ctx, couchdb.WithWait(100*time.Millisecond)))
+
+		info, err := db.Info(e.ctx)
+		require.NoError(t, err)
+		assert.False(t, info.CompactRunning)
+	})
+
+	t.Run("CompactViews", func(t *testing.T) {
+		require.NoError(t, db.CompactViews(e.ctx, "test", couchdb.WithWait(100*time.Millisecond)))
+	})
+
+	t.Run("CompactViewsOfMissingDesignDocument", func(t *testing.T) {
+		err := db.CompactViews(e.ctx, "missing")
+		assert.ErrorIs(t, err, couchdb.ErrNotFound)
+	})
+
+	t.Run("ViewCleanup", func(t *testing.T) {
+		require.NoError(t, db.ViewCleanup(e.ctx))
+	})
+
+	t.Run("EnsureFullCommit", func(t *testing.T) {
+		require.NoError(t, db.EnsureFullCommit(e.ctx))
+	})
+}
+
+func TestCompactWait(t *testing.T) {
+	mutex := sync.Mutex{}
+	infos := 0
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/test/_compact", jsonResponse(202, `{"ok":true}`))
+	httpClient.handle("http://a/test", func(stubRequest) stubResponse {
+		mutex.Lock()
+		defer mutex.Unlock()
+		infos++
+		if infos < 3 {
+			return stubResponse{statusCode: 200, body: `{"db_name":"test","compact_running":true}`}
+		}
+		return stubResponse{statusCode: 200, body: `{"db_name":"test","compact_running":false}`}
+	})
+
+	db := couchdb.NewDatabase(client, "test")
+	require.NoError(t, db.Compact(context.Background(), couchdb.WithWait(time.Millisecond)))
+
+	mutex.Lock()
+	assert.Equal(t, 3, infos)
+	mutex.Unlock()
+	assert.Equal(t, "application/json", httpClient.requests[0].header.Get("Content-Type"))
+}
+
+func TestCompactWaitForStart(t *testing.T) {
+	mutex := sync.Mutex{}
+	infos := 0
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/test/_compact", jsonResponse(202, `{"ok":true}`))
+	httpClient.handle("http://a/test", func(stubRequest) stubResponse {
+		mutex.Lock()
+		defer mutex.Unlock()
+		infos++
+		if infos == 2 {
+			return stubResponse{statusCode: 200, body: `{"db_name":"test","compact_running":true}`}
+		}
+		return stubResponse{statusCode: 200, body: `{"db_name":"test","compact_running":false}`}
+	})
+
+	db := couchdb.NewDatabase(client, "test")
+
+	t.Run("Started", func(t *testing.T) {
+		require.NoError(t, db.Compact(context.Background(), couchdb.WithWait(time.Millisecond)))
+
+		mutex.Lock()
+		assert.Equal(t, 3, infos)
+		mutex.Unlock()
+	})
+
+	t.Run("NeverStarted", func(t *testing.T) {
+		start := time.Now()
+		require.NoError(t, db.Compact(context.Background(),
+			couchdb.WithWait(time.Millisecond),
+			couchdb.WithStartGrace(20*time.Millisecond)))
+
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	})
+}
+
+func TestViewMaintenanceWait(t *testing.T) {
+	mutex := sync.Mutex{}
+	polls := 0
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/test/_compact/test", jsonResponse(202, `{"ok":true}`))
+	httpClient.handle("http://a/test/_view_cleanup", jsonResponse(202, `{"ok":true}`))
+	httpClient.handle("http://a/_active_tasks", func(stubRequest) stubResponse {
+		mutex.Lock()
+		defer mutex.Unlock()
+		polls++
+		switch polls {
+		case 2:
+			return stubResponse{statusCode: 200, body: `[{"type":"view_compaction","database":"shards/00000000-ffffffff/test.1","design_document":"_design/test"}]`}
+		case 5:
+			return stubResponse{statusCode: 200, body: `[{"type":"indexer","database":"shards/00000000-ffffffff/test.1","design_document":"_design/other"}]`}
+		}
+		return stubResponse{statusCode: 200, body: `[]`}
+	})
+
+	db := couchdb.NewDatabase(client, "test")
+
+	t.Run("CompactViews", func(t *testing.T) {
+		require.NoError(t, db.CompactViews(context.Background(), "test", couchdb.WithWait(time.Millisecond)))
+
+		mutex.Lock()
+		assert.Equal(t, 3, polls)
+		mutex.Unlock()
+	})
+
+	t.Run("ViewCleanup", func(t *testing.T) {
+		require.NoError(t, db.ViewCleanup(context.Background(), couchdb.WithWait(time.Millisecond)))
+
+		mutex.Lock()
+		assert.Equal(t, 6, polls)
+		mutex.Unlock()
+	})
+}
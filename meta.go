@@ -0,0 +1,135 @@
+package couchdb
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+// Various errors.
+var (
+	ErrUnsupportedType = errors.New("unsupported type")
+)
+
+// Meta holds the meta fields of a document. If it's embedded into a struct, either as value or as pointer,
+// the fields are mapped automatically by `TypedDocument`.
+type Meta struct {
+	ID          string                      `json:"_id,omitempty"`
+	Revision    string                      `json:"_rev,omitempty"`
+	Deleted     bool                        `json:"_deleted,omitempty"`
+	Attachments map[string]value.Attachment `json:"_attachments,omitempty"`
+	Conflicts   []string                    `json:"_conflicts,omitempty"`
+}
+
+// Various meta field names.
+const (
+	metaID          = "_id"
+	metaRevision    = "_rev"
+	metaDeleted     = "_deleted"
+	metaAttachments = "_attachments"
+	metaConflicts   = "_conflicts"
+)
+
+// metaFields holds the index paths of the meta fields of a struct type.
+type metaFields map[string][]int
+
+var metaFieldsCache sync.Map
+
+// metaFieldsOf returns the meta fields of the provided struct type. The fields are identified by their
+// json names, including the fields of embedded structs.
+func metaFieldsOf(t reflect.Type) (metaFields, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %s is not a struct", ErrUnsupportedType, t)
+	}
+	if mf, ok := metaFieldsCache.Load(t); ok {
+		return mf.(metaFields), nil
+	}
+
+	mf := metaFields{}
+	collectMetaFields(t, nil, mf, map[reflect.Type]bool{})
+	metaFieldsCache.Store(t, mf)
+
+	return mf, nil
+}
+
+// collectMetaFields collects the meta fields of the provided struct type. Embedded structs are followed,
+// also if they're embedded as pointers.
+func collectMetaFields(t reflect.Type, index []int, mf metaFields, visited map[reflect.Type]bool) {
+	if visited[t] {
+		return
+	}
+	visited[t] = true
+	defer delete(visited, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldIndex := append(append([]int{}, index...), i)
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" && field.Anonymous {
+			switch {
+			case field.Type.Kind() == reflect.Struct:
+				collectMetaFields(field.Type, fieldIndex, mf, visited)
+				continue
+			case field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct:
+				collectMetaFields(field.Type.Elem(), fieldIndex, mf, visited)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		switch name {
+		case metaID, metaRevision, metaDeleted, metaAttachments, metaConflicts:
+			if _, ok := mf[name]; !ok || len(fieldIndex) < len(mf[name]) {
+				mf[name] = fieldIndex
+			}
+		}
+	}
+}
+
+// field returns the meta field with the provided name. Nil pointers to embedded structs on the way are
+// allocated if requested, otherwise the field is reported as missing.
+func (mf metaFields) field(v reflect.Value, name string, allocate bool) (reflect.Value, bool) {
+	index, ok := mf[name]
+	if !ok {
+		return reflect.Value{}, false
+	}
+	for position, i := range index {
+		if position > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !allocate || !v.CanSet() {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v, true
+}
+
+func (mf metaFields) string(v reflect.Value, name string) string {
+	field, ok := mf.field(v, name, false)
+	if !ok || field.Kind() != reflect.String {
+		return ""
+	}
+	return field.String()
+}
+
+func (mf metaFields) setString(v reflect.Value, name, value string) {
+	if field, ok := mf.field(v, name, value != ""); ok && field.Kind() == reflect.String {
+		field.SetString(value)
+	}
+}
+
+func (mf metaFields) setBool(v reflect.Value, name string, value bool) {
+	if field, ok := mf.field(v, name, value); ok && field.Kind() == reflect.Bool {
+		field.SetBool(value)
+	}
+}
@@ -0,0 +1,230 @@
+// Package metrics provides a couchdb.Observer that collects request counters and latency histograms
+// and exposes them in the prometheus text exposition format.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/simia-tech/couchdb"
+)
+
+// DefaultBuckets holds the default latency histogram buckets in seconds.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Collector implements a `couchdb.Observer` that collects metrics about the performed requests.
+type Collector struct {
+	namespace string
+	buckets   []float64
+
+	mutex     sync.Mutex
+	inFlight  map[key]int64
+	requests  map[key]uint64
+	errors    map[key]uint64
+	sent      map[key]uint64
+	received  map[key]uint64
+	durations map[key]*histogram
+}
+
+var _ couchdb.Observer = &Collector{}
+
+type key struct {
+	method string
+	path   string
+	status string
+}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// Option defines a function that can modify the collector parameters.
+type Option func(*Collector)
+
+// WithNamespace returns an option that sets the prefix of all metric names. It defaults to `couchdb`.
+func WithNamespace(value string) Option {
+	return func(c *Collector) {
+		c.namespace = value
+	}
+}
+
+// WithBuckets returns an option that sets the latency histogram buckets in seconds.
+func WithBuckets(value ...float64) Option {
+	return func(c *Collector) {
+		c.buckets = append([]float64{}, value...)
+		sort.Float64s(c.buckets)
+	}
+}
+
+// NewCollector returns a new collector configured with the provided options.
+func NewCollector(options ...Option) *Collector {
+	c := &Collector{
+		namespace: "couchdb",
+		buckets:   DefaultBuckets,
+		inFlight:  map[key]int64{},
+		requests:  map[key]uint64{},
+		errors:    map[key]uint64{},
+		sent:      map[key]uint64{},
+		received:  map[key]uint64{},
+		durations: map[key]*histogram{},
+	}
+	for _, o := range options {
+		o(c)
+	}
+	return c
+}
+
+// RequestStarted implements `couchdb.Observer`.
+func (c *Collector) RequestStarted(ctx context.Context, ri couchdb.RequestInfo) context.Context {
+	c.mutex.Lock()
+	c.inFlight[key{method: ri.Method, path: ri.PathTemplate}]++
+	c.mutex.Unlock()
+	return ctx
+}
+
+// RequestFinished implements `couchdb.Observer`.
+func (c *Collector) RequestFinished(_ context.Context, ri couchdb.RequestInfo, response couchdb.ResponseInfo) {
+	k := key{method: ri.Method, path: ri.PathTemplate}
+	status := "error"
+	if response.Err == nil {
+		status = strconv.Itoa(response.StatusCode)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.inFlight[k]--
+	c.requests[key{method: ri.Method, path: ri.PathTemplate, status: status}]++
+	if response.Err != nil || response.StatusCode >= http.StatusInternalServerError {
+		c.errors[k]++
+	}
+	c.sent[k] += uint64(response.BytesSent)
+	c.received[k] += uint64(response.BytesReceived)
+
+	h, ok := c.durations[k]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(c.buckets))}
+		c.durations[k] = h
+	}
+	seconds := response.Duration.Seconds()
+	for index, bucket := range c.buckets {
+		if seconds <= bucket {
+			h.counts[index]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// ServeHTTP writes the collected metrics in the prometheus text format.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	if _, err := c.WriteTo(w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// WriteTo writes the collected metrics in the prometheus text format to the provided writer.
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	b := &strings.Builder{}
+	c.writeGauge(b, "requests_in_flight", "Number of couchdb requests in flight.", c.inFlight)
+	c.writeCounter(b, "requests_total", "Total number of couchdb requests.", c.requests)
+	c.writeCounter(b, "request_errors_total", "Total number of failed couchdb requests.", c.errors)
+	c.writeCounter(b, "request_bytes_total", "Total number of bytes sent to couchdb.", c.sent)
+	c.writeCounter(b, "response_bytes_total", "Total number of bytes received from couchdb.", c.received)
+	c.writeHistogram(b, "request_duration_seconds", "Duration of couchdb requests in seconds.")
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func (c *Collector) writeGauge(b *strings.Builder, name, help string, values map[key]int64) {
+	name = c.name(name)
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+	keys := []key{}
+	for k := range values {
+		keys = append(keys, k)
+	}
+	for _, k := range sortKeys(keys) {
+		fmt.Fprintf(b, "%s%s %d\n", name, k.labels(), values[k])
+	}
+}
+
+func (c *Collector) writeCounter(b *strings.Builder, name, help string, values map[key]uint64) {
+	name = c.name(name)
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+	keys := []key{}
+	for k := range values {
+		keys = append(keys, k)
+	}
+	for _, k := range sortKeys(keys) {
+		fmt.Fprintf(b, "%s%s %d\n", name, k.labels(), values[k])
+	}
+}
+
+func (c *Collector) writeHistogram(b *strings.Builder, name, help string) {
+	name = c.name(name)
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name)
+	keys := []key{}
+	for k := range c.durations {
+		keys = append(keys, k)
+	}
+	for _, k := range sortKeys(keys) {
+		h := c.durations[k]
+		for index, bucket := range c.buckets {
+			fmt.Fprintf(b, "%s_bucket%s %d\n", name, k.labelsWith("le", formatFloat(bucket)), h.counts[index])
+		}
+		fmt.Fprintf(b, "%s_bucket%s %d\n", name, k.labelsWith("le", "+Inf"), h.count)
+		fmt.Fprintf(b, "%s_sum%s %s\n", name, k.labels(), formatFloat(h.sum))
+		fmt.Fprintf(b, "%s_count%s %d\n", name, k.labels(), h.count)
+	}
+}
+
+func (c *Collector) name(name string) string {
+	if c.namespace == "" {
+		return name
+	}
+	return c.namespace + "_" + name
+}
+
+func (k key) labels() string {
+	return k.labelsWith("", "")
+}
+
+func (k key) labelsWith(name, value string) string {
+	labels := []string{
+		fmt.Sprintf("method=%q", k.method),
+		fmt.Sprintf("path=%q", k.path),
+	}
+	if k.status != "" {
+		labels = append(labels, fmt.Sprintf("status=%q", k.status))
+	}
+	if name != "" {
+		labels = append(labels, fmt.Sprintf("%s=%q", name, value))
+	}
+	return "{" + strings.Join(labels, ",") + "}"
+}
+
+func (k key) String() string {
+	return k.method + " " + k.path + " " + k.status
+}
+
+func sortKeys(keys []key) []key {
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	return keys
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
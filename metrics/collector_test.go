@@ -0,0 +1,43 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+	"github.com/simia-tech/couchdb/metrics"
+)
+
+func TestCollector(t *testing.T) {
+	ctx := context.Background()
+	c := metrics.NewCollector(metrics.WithBuckets(0.1, 1))
+
+	ri := couchdb.RequestInfo{Method: "GET", Path: "/test/abc", PathTemplate: "/{db}/{docid}"}
+	c.RequestFinished(c.RequestStarted(ctx, ri), ri, couchdb.ResponseInfo{
+		StatusCode: 200, Duration: 50 * time.Millisecond, BytesReceived: 10,
+	})
+	c.RequestFinished(c.RequestStarted(ctx, ri), ri, couchdb.ResponseInfo{
+		Err: errors.New("failed"), Duration: 500 * time.Millisecond,
+	})
+
+	b := &strings.Builder{}
+	_, err := c.WriteTo(b)
+	require.NoError(t, err)
+
+	output := b.String()
+	assert.Contains(t, output, `couchdb_requests_in_flight{method="GET",path="/{db}/{docid}"} 0`)
+	assert.Contains(t, output, `couchdb_requests_total{method="GET",path="/{db}/{docid}",status="200"} 1`)
+	assert.Contains(t, output, `couchdb_requests_total{method="GET",path="/{db}/{docid}",status="error"} 1`)
+	assert.Contains(t, output, `couchdb_request_errors_total{method="GET",path="/{db}/{docid}"} 1`)
+	assert.Contains(t, output, `couchdb_response_bytes_total{method="GET",path="/{db}/{docid}"} 10`)
+	assert.Contains(t, output, `couchdb_request_duration_seconds_bucket{method="GET",path="/{db}/{docid}",le="0.1"} 1`)
+	assert.Contains(t, output, `couchdb_request_duration_seconds_bucket{method="GET",path="/{db}/{docid}",le="1"} 2`)
+	assert.Contains(t, output, `couchdb_request_duration_seconds_bucket{method="GET",path="/{db}/{docid}",le="+Inf"} 2`)
+	assert.Contains(t, output, `couchdb_request_duration_seconds_count{method="GET",path="/{db}/{docid}"} 2`)
+}
@@ -0,0 +1,211 @@
+package couchdb
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Observer defines an interface to observe the requests that are performed by the client.
+type Observer interface {
+	// RequestStarted is called before a request is sent. The returned context is used for the
+	// request and is passed to RequestFinished.
+	RequestStarted(context.Context, RequestInfo) context.Context
+
+	// RequestFinished is called after the response body has been closed or the request failed.
+	RequestFinished(context.Context, RequestInfo, ResponseInfo)
+}
+
+// RequestInfo holds infos about a request.
+type RequestInfo struct {
+	Method       string
+	Path         string
+	PathTemplate string
+	Database     string
+}
+
+// ResponseInfo holds infos about the outcome of a request.
+type ResponseInfo struct {
+	Endpoint      string
+	StatusCode    int
+	Duration      time.Duration
+	BytesSent     int64
+	BytesReceived int64
+	Err           error
+}
+
+func newRequestInfo(method, path string) RequestInfo {
+	ri := RequestInfo{
+		Method:       method,
+		Path:         path,
+		PathTemplate: pathTemplate(path),
+	}
+	if segments := pathSegments(path); len(segments) > 0 && !strings.HasPrefix(segments[0], "_") {
+		ri.Database, _ = url.PathUnescape(segments[0])
+	}
+	return ri
+}
+
+func (c *Client) observeStart(ctx context.Context, ri RequestInfo) context.Context {
+	for _, o := range c.observers {
+		ctx = o.RequestStarted(ctx, ri)
+	}
+	return ctx
+}
+
+func (c *Client) observeFinish(
+	ctx context.Context,
+	ri RequestInfo,
+	result ResponseInfo,
+	start time.Time,
+	responseReader io.ReadCloser,
+) io.ReadCloser {
+	if len(c.observers) == 0 {
+		return responseReader
+	}
+
+	finish := func(bytesReceived int64) {
+		result.Duration = time.Since(start)
+		result.BytesReceived = bytesReceived
+		for _, o := range c.observers {
+			o.RequestFinished(ctx, ri, result)
+		}
+	}
+
+	if responseReader == nil {
+		finish(0)
+		return nil
+	}
+	return &observedReadCloser{ReadCloser: responseReader, finish: finish}
+}
+
+// pathTemplate replaces the database names, document ids and other names in the provided path
+// with placeholders, e.g. `/db/abc` becomes `/{db}/{docid}`.
+func pathTemplate(path string) string {
+	segments := pathSegments(path)
+	if len(segments) == 0 {
+		return "/"
+	}
+
+	template := []string{}
+	if strings.HasPrefix(segments[0], "_") {
+		template = append(template, segments[0])
+		for _, segment := range segments[1:] {
+			template = append(template, placeholder(segment, "{name}"))
+		}
+		return "/" + strings.Join(template, "/")
+	}
+
+	template = append(template, "{db}")
+	template = append(template, databasePathTemplate(segments[1:])...)
+	return "/" + strings.Join(template, "/")
+}
+
+func databasePathTemplate(segments []string) []string {
+	if len(segments) == 0 {
+		return nil
+	}
+
+	template := []string{}
+	switch segments[0] {
+	case "_design":
+		template = append(template, "_design")
+		if len(segments) > 1 {
+			template = append(template, "{ddoc}")
+		}
+		if len(segments) > 2 {
+			template = append(template, placeholder(segments[2], "{attname}"))
+		}
+		if len(segments) > 3 {
+			template = append(template, "{name}")
+		}
+		return template
+	case "_local":
+		template = append(template, "_local")
+		if len(segments) > 1 {
+			template = append(template, "{docid}")
+		}
+		return template
+	case "_partition":
+		template = append(template, "_partition")
+		if len(segments) > 1 {
+			template = append(template, "{partition}")
+		}
+		if len(segments) > 2 {
+			template = append(template, databasePathTemplate(segments[2:])...)
+		}
+		return template
+	}
+
+	if strings.HasPrefix(segments[0], "_") {
+		template = append(template, segments[0])
+		for _, segment := range segments[1:] {
+			template = append(template, placeholder(segment, "{name}"))
+		}
+		return template
+	}
+
+	template = append(template, "{docid}")
+	if len(segments) > 1 {
+		template = append(template, "{attname}")
+	}
+	return template
+}
+
+func placeholder(segment, name string) string {
+	if strings.HasPrefix(segment, "_") {
+		return segment
+	}
+	return name
+}
+
+func pathSegments(path string) []string {
+	if index := strings.IndexByte(path, '?'); index >= 0 {
+		path = path[:index]
+	}
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+type countingReader struct {
+	reader io.Reader
+	n      int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.reader.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+func (cr *countingReader) count() int64 {
+	if cr == nil {
+		return 0
+	}
+	return cr.n
+}
+
+type observedReadCloser struct {
+	io.ReadCloser
+	n      int64
+	once   sync.Once
+	finish func(int64)
+}
+
+func (orc *observedReadCloser) Read(p []byte) (int, error) {
+	n, err := orc.ReadCloser.Read(p)
+	orc.n += int64(n)
+	return n, err
+}
+
+func (orc *observedReadCloser) Close() error {
+	err := orc.ReadCloser.Close()
+	orc.once.Do(func() { orc.finish(orc.n) })
+	return err
+}
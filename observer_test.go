@@ -0,0 +1,73 @@
+package couchdb_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+)
+
+type recordingObserver struct {
+	mutex     sync.Mutex
+	started   []couchdb.RequestInfo
+	finished  []couchdb.ResponseInfo
+	templates []string
+}
+
+func (o *recordingObserver) RequestStarted(ctx context.Context, ri couchdb.RequestInfo) context.Context {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.started = append(o.started, ri)
+	return ctx
+}
+
+func (o *recordingObserver) RequestFinished(_ context.Context, ri couchdb.RequestInfo, response couchdb.ResponseInfo) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.finished = append(o.finished, response)
+	o.templates = append(o.templates, ri.PathTemplate)
+}
+
+func TestObserver(t *testing.T) {
+	ctx := context.Background()
+
+	observer := &recordingObserver{}
+	client, httpClient := newStubClient(t, couchdb.WithObserver(observer))
+	httpClient.handle("http://a/", jsonResponse(200, `{"couchdb":"Welcome"}`))
+	httpClient.handle("http://a/test", jsonResponse(201, `{"ok":true,"id":"abc","rev":"1-x"}`))
+
+	_, err := client.InstanceInfo(ctx)
+	require.NoError(t, err)
+
+	db := couchdb.NewDatabase(client, "test")
+	document := couchdb.NewDocument(db, "abc", "")
+	require.NoError(t, document.Store(ctx, map[string]interface{}{"test": "value"}))
+
+	assert.Equal(t, []string{"/", "/{db}/{docid}"}, observer.templates)
+	require.Len(t, observer.started, 2)
+	assert.Equal(t, "test", observer.started[1].Database)
+	assert.Equal(t, "PUT", observer.started[1].Method)
+
+	require.Len(t, observer.finished, 2)
+	assert.Equal(t, "http://a", observer.finished[1].Endpoint)
+	assert.Equal(t, 201, observer.finished[1].StatusCode)
+	assert.Equal(t, int64(len(`{"test":"value"}`+"\n")), observer.finished[1].BytesSent)
+	assert.Equal(t, int64(len(`{"ok":true,"id":"abc","rev":"1-x"}`)), observer.finished[1].BytesReceived)
+}
+
+func TestObserverFailure(t *testing.T) {
+	observer := &recordingObserver{}
+	client, httpClient := newStubClient(t, couchdb.WithObserver(observer))
+	httpClient.handle("http://a/", unreachable())
+
+	_, err := client.AllDatabases(context.Background())
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"/_all_dbs"}, observer.templates)
+	require.Len(t, observer.finished, 1)
+	assert.ErrorIs(t, observer.finished[0].Err, errStubUnreachable)
+}
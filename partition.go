@@ -0,0 +1,128 @@
+package couchdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+// Various errors.
+var (
+	ErrInvalidPartition = errors.New("invalid partition")
+)
+
+const (
+	featurePartitioned = "partitioned"
+	partitionSeparator = ":"
+)
+
+// Partition implements the partition-scoped methods of a partitioned database.
+type Partition struct {
+	database *Database
+	name     string
+}
+
+// Partition returns the partition with the provided name of the database.
+func (db *Database) Partition(name string) *Partition {
+	return &Partition{
+		database: db,
+		name:     name,
+	}
+}
+
+// ValidatePartition checks the provided partition name. It must not be empty, must not start with an
+// underscore and must not contain a colon.
+func ValidatePartition(name string) error {
+	if name == "" || strings.HasPrefix(name, "_") || strings.Contains(name, partitionSeparator) {
+		return fmt.Errorf("%w: %q", ErrInvalidPartition, name)
+	}
+	return nil
+}
+
+// ValidatePartitionedID checks if the provided document id has the form `partition:docid`, that is
+// required in partitioned databases. Design and local documents are not partitioned.
+func ValidatePartitionedID(id string) error {
+	if strings.HasPrefix(id, designPrefix) || strings.HasPrefix(id, localPrefix) {
+		return nil
+	}
+	index := strings.Index(id, partitionSeparator)
+	if index < 0 || index == len(id)-1 {
+		return fmt.Errorf("%w: id %q", ErrInvalidPartition, id)
+	}
+	return ValidatePartition(id[:index])
+}
+
+// Name returns the partition's name.
+func (p *Partition) Name() string {
+	return p.name
+}
+
+// ID returns the id of the document with the provided id in the partition.
+func (p *Partition) ID(id string) string {
+	return p.name + partitionSeparator + id
+}
+
+// Document returns the document with the provided id in the partition. The id is expected without the
+// partition prefix and the resulting id is validated using `ValidatePartitionedID`.
+func (p *Partition) Document(id, revision string) (*Document, error) {
+	partitionedID := p.ID(id)
+	if err := ValidatePartitionedID(partitionedID); err != nil {
+		return nil, err
+	}
+	return NewDocument(p.database, partitionedID, revision), nil
+}
+
+// Info fetches infos about the partition.
+func (p *Partition) Info(ctx context.Context) (value.PartitionInfo, error) {
+	r := value.PartitionInfo{}
+	if err := ValidatePartition(p.name); err != nil {
+		return r, err
+	}
+	if err := p.database.requestJSON(ctx, http.MethodGet, p.path(), nil, nil, &r); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// AllDocs fetches the rows of the `_all_docs` view, that belong to the partition.
+func (p *Partition) AllDocs(ctx context.Context, options ...ViewOption) (value.ViewResult, error) {
+	if err := ValidatePartition(p.name); err != nil {
+		return value.ViewResult{}, err
+	}
+	return p.database.view(ctx, p.path("_all_docs"), options)
+}
+
+// View fetches the rows of the provided view, that belong to the partition. The design document name is
+// expected without the `_design/` prefix.
+func (p *Partition) View(ctx context.Context, designDocument, view string, options ...ViewOption) (value.ViewResult, error) {
+	if err := ValidatePartition(p.name); err != nil {
+		return value.ViewResult{}, err
+	}
+	return p.database.view(ctx, p.path(viewSegments(designDocument, view)...), options)
+}
+
+// Find performs the provided mango query on the partition.
+func (p *Partition) Find(ctx context.Context, query value.FindQuery) (value.FindResult, error) {
+	if err := ValidatePartition(p.name); err != nil {
+		return value.FindResult{}, err
+	}
+	return p.database.find(ctx, p.path("_find"), query)
+}
+
+// Explain returns the index and the parameters, that would be used to perform the provided mango query on
+// the partition.
+func (p *Partition) Explain(ctx context.Context, query value.FindQuery) (value.ExplainResult, error) {
+	if err := ValidatePartition(p.name); err != nil {
+		return value.ExplainResult{}, err
+	}
+	return p.database.explain(ctx, p.path("_explain"), query)
+}
+
+// path returns the path to the partition followed by the provided escaped segments.
+func (p *Partition) path(segments ...string) string {
+	return p.database.path(append([]string{"_partition", escapePathSegment(p.name)}, segments...)...)
+}
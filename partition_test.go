@@ -0,0 +1,114 @@
+package couchdb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+	"github.com/simia-tech/couchdb/value"
+)
+
+func TestPartition(t *testing.T) {
+	e := setUpTestEnvironment(t)
+	defer e.tearDown()
+
+	db := couchdb.NewDatabase(e.client, "test")
+	require.NoError(t, db.Create(e.ctx, couchdb.WithPartitioned(), couchdb.WithShards(2)))
+	defer db.Delete(e.ctx)
+
+	partition := db.Partition("tenant")
+	document, err := partition.Document("one", "")
+	require.NoError(t, err)
+	require.NoError(t, document.Store(e.ctx, map[string]interface{}{"test": "one"}))
+	require.NoError(t, couchdb.NewDocument(db, "other:two", "").Store(e.ctx, map[string]interface{}{"test": "two"}))
+	require.NoError(t, couchdb.NewDocument(db, "_design/test", "").Store(e.ctx, map[string]interface{}{
+		"views": map[string]interface{}{
+			"test": map[string]interface{}{"map": "function (doc) { emit(doc.test, null); }"},
+		},
+	}))
+
+	t.Run("Info", func(t *testing.T) {
+		info, err := partition.Info(e.ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, "tenant", info.Partition)
+		assert.Equal(t, uint(1), info.DocumentCount)
+	})
+
+	t.Run("AllDocs", func(t *testing.T) {
+		result, err := partition.AllDocs(e.ctx)
+		require.NoError(t, err)
+
+		require.Len(t, result.Rows, 1)
+		assert.Equal(t, "tenant:one", result.Rows[0].ID)
+	})
+
+	t.Run("View", func(t *testing.T) {
+		result, err := partition.View(e.ctx, "test", "test")
+		require.NoError(t, err)
+
+		require.Len(t, result.Rows, 1)
+		assert.Equal(t, "tenant:one", result.Rows[0].ID)
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		result, err := partition.Find(e.ctx, value.FindQuery{Selector: map[string]interface{}{"test": map[string]interface{}{"$gt": nil}}})
+		require.NoError(t, err)
+
+		assert.Len(t, result.Docs, 1)
+	})
+
+	t.Run("Explain", func(t *testing.T) {
+		result, err := partition.Explain(e.ctx, value.FindQuery{Selector: map[string]interface{}{"test": "one"}})
+		require.NoError(t, err)
+
+		assert.Equal(t, "test", result.DBName)
+		assert.NotEmpty(t, result.Index.Type)
+	})
+}
+
+func TestCreatePartitionedWithoutFeature(t *testing.T) {
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/", jsonResponse(200, `{"couchdb":"Welcome","features":["reshard"]}`))
+
+	err := couchdb.NewDatabase(client, "test").Create(context.Background(), couchdb.WithPartitioned())
+	assert.ErrorIs(t, err, couchdb.ErrUnsupportedFeature)
+	assert.Equal(t, []string{"http://a/"}, httpClient.urls())
+}
+
+func TestCreateOptions(t *testing.T) {
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/", jsonResponse(200, `{"couchdb":"Welcome","features":["partitioned"]}`))
+	httpClient.handle("http://a/test", jsonResponse(201, `{"ok":true}`))
+
+	require.NoError(t, couchdb.NewDatabase(client, "test").Create(context.Background(),
+		couchdb.WithShards(4), couchdb.WithReplicas(2), couchdb.WithPartitioned()))
+	assert.Equal(t, []string{"http://a/", "http://a/test?n=2&partitioned=true&q=4"}, httpClient.urls())
+}
+
+func TestValidatePartitionedID(t *testing.T) {
+	for _, id := range []string{"tenant:one", "tenant:a:b", "_design/test", "_local/test"} {
+		assert.NoError(t, couchdb.ValidatePartitionedID(id), id)
+	}
+	for _, id := range []string{"", "one", ":one", "tenant:", "_tenant:one"} {
+		assert.ErrorIs(t, couchdb.ValidatePartitionedID(id), couchdb.ErrInvalidPartition, id)
+	}
+}
+
+func TestPartitionDocument(t *testing.T) {
+	db := couchdb.NewDatabase(nil, "test")
+
+	document, err := db.Partition("tenant").Document("one", "1-a")
+	require.NoError(t, err)
+	assert.Equal(t, "tenant:one", document.ID())
+	assert.Equal(t, "1-a", document.Revision())
+
+	_, err = db.Partition("tenant").Document("", "")
+	assert.ErrorIs(t, err, couchdb.ErrInvalidPartition)
+
+	_, err = db.Partition("_tenant").Document("one", "")
+	assert.ErrorIs(t, err, couchdb.ErrInvalidPartition)
+}
@@ -0,0 +1,103 @@
+package couchdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Various errors.
+var (
+	ErrInvalidDatabaseName = errors.New("invalid database name")
+)
+
+const (
+	designPrefix          = "_design/"
+	localPrefix           = "_local/"
+	maxDatabaseNameLength = 238
+)
+
+var (
+	databaseNameRegexp  = regexp.MustCompile(`^[a-z][a-z0-9_$()+/-]*$`)
+	systemDatabaseNames = map[string]bool{
+		"_users":          true,
+		"_replicator":     true,
+		"_global_changes": true,
+	}
+)
+
+// ValidateDatabaseName checks the provided name against couchdb's naming rules. The name has to start
+// with a lowercase letter and may only contain lowercase letters, digits and the characters `_$()+-/`.
+func ValidateDatabaseName(name string) error {
+	if systemDatabaseNames[name] {
+		return nil
+	}
+	if len(name) > maxDatabaseNameLength {
+		return fmt.Errorf("%w: %s: exceeds %d characters", ErrInvalidDatabaseName, name, maxDatabaseNameLength)
+	}
+	if !databaseNameRegexp.MatchString(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidDatabaseName, name)
+	}
+	return nil
+}
+
+// databasePath returns the path to the provided database followed by the provided segments. The segments
+// are expected to be escaped already.
+func databasePath(database string, segments ...string) string {
+	return "/" + strings.Join(append([]string{escapePathSegment(database)}, segments...), "/")
+}
+
+// documentPath returns the path to the provided document followed by the provided segments. The segments
+// are expected to be escaped already.
+func documentPath(database, id string, segments ...string) string {
+	return databasePath(database, append([]string{escapeID(id)}, segments...)...)
+}
+
+// escapeID escapes the provided document id, but keeps the `_design/` and `_local/` prefixes intact.
+func escapeID(id string) string {
+	for _, prefix := range []string{designPrefix, localPrefix} {
+		if strings.HasPrefix(id, prefix) {
+			return prefix + escapePathSegment(strings.TrimPrefix(id, prefix))
+		}
+	}
+	return escapePathSegment(id)
+}
+
+// escapeAttachmentName escapes the provided attachment name. Slashes are kept, since couchdb treats the
+// whole remaining path as the attachment name.
+func escapeAttachmentName(name string) string {
+	segments := strings.Split(name, "/")
+	for index, segment := range segments {
+		segments[index] = escapePathSegment(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func escapePathSegment(segment string) string {
+	return strings.ReplaceAll(url.PathEscape(segment), "+", "%2B")
+}
+
+// withQuery appends the provided query to the path.
+func withQuery(path string, query url.Values) string {
+	if len(query) == 0 {
+		return path
+	}
+	return path + "?" + query.Encode()
+}
+
+// setJSONQuery sets the json encoding of the provided value as a query parameter, like it's
+// required for view keys.
+func setJSONQuery(query url.Values, key string, value interface{}) error {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("json encode %s: %w", key, err)
+	}
+	query.Set(key, strings.TrimSuffix(buffer.String(), "\n"))
+	return nil
+}
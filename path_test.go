@@ -0,0 +1,62 @@
+package couchdb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+)
+
+func TestValidateDatabaseName(t *testing.T) {
+	for _, name := range []string{"test", "a1", "tenant/orders", "a_$()+-", "_users", "_replicator"} {
+		assert.NoError(t, couchdb.ValidateDatabaseName(name), name)
+	}
+	for _, name := range []string{"", "Test", "1a", "_test", "a b", "a?b", "a#b", string(make([]byte, 239))} {
+		assert.ErrorIs(t, couchdb.ValidateDatabaseName(name), couchdb.ErrInvalidDatabaseName, name)
+	}
+}
+
+func TestPathEscaping(t *testing.T) {
+	ctx := context.Background()
+
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/", jsonResponse(200, `{"ok":true}`))
+
+	tcs := []struct {
+		database string
+		id       string
+		url      string
+	}{
+		{"test", "abc", "http://a/test/abc"},
+		{"tenant/orders", "abc", "http://a/tenant%2Forders/abc"},
+		{"test", "a/b", "http://a/test/a%2Fb"},
+		{"test", "a?b#c", "http://a/test/a%3Fb%23c"},
+		{"test", "a+b c", "http://a/test/a%2Bb%20c"},
+		{"test", "_design/views", "http://a/test/_design/views"},
+		{"test", "_design/a/b", "http://a/test/_design/a%2Fb"},
+		{"test", "_local/checkpoint", "http://a/test/_local/checkpoint"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.id, func(t *testing.T) {
+			httpClient.reset()
+
+			db := couchdb.NewDatabase(client, tc.database)
+			require.NoError(t, couchdb.NewDocument(db, tc.id, "").Fetch(ctx, &map[string]interface{}{}))
+
+			assert.Equal(t, []string{tc.url}, httpClient.urls())
+		})
+	}
+
+	t.Run("InvalidDatabaseName", func(t *testing.T) {
+		httpClient.reset()
+
+		db := couchdb.NewDatabase(client, "Invalid")
+		err := db.Create(ctx)
+		assert.ErrorIs(t, err, couchdb.ErrInvalidDatabaseName)
+
+		assert.Empty(t, httpClient.urls())
+	})
+}
@@ -0,0 +1,131 @@
+package couchdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+// Limits of a single purge request. They match the defaults of `max_document_id_number` and
+// `max_revisions_number` of the purge section in the server config.
+const (
+	purgeMaxDocuments = 100
+	purgeMaxRevisions = 1000
+)
+
+// Purge removes the provided revisions of the provided document ids from the database. Unlike a deletion,
+// no tombstone is left behind and the purge is not replicated. The revisions are sent in batches, that stay
+// within the server's default limits of a purge request, and the results of the batches are merged.
+func (db *Database) Purge(ctx context.Context, revisions map[string][]string) (value.PurgeResult, error) {
+	result := value.PurgeResult{Purged: map[string][]string{}}
+	for _, batch := range purgeBatches(revisions) {
+		r := value.PurgeResult{}
+		if err := db.requestJSON(ctx, http.MethodPost, db.path("_purge"), nil, batch, &r); err != nil {
+			return result, err
+		}
+		result.PurgeSeq = r.PurgeSeq
+		for id, purged := range r.Purged {
+			result.Purged[id] = append(result.Purged[id], purged...)
+		}
+	}
+	return result, nil
+}
+
+// PurgeDocuments removes all revisions of the documents with the provided ids from the database. The leaf
+// revisions of the documents, including the tombstones, are looked up first using bulk get requests.
+// Missing documents are skipped.
+func (db *Database) PurgeDocuments(ctx context.Context, ids ...string) (value.PurgeResult, error) {
+	revisions := map[string][]string{}
+	for start := 0; start < len(ids); start += purgeMaxDocuments {
+		end := start + purgeMaxDocuments
+		if end > len(ids) {
+			end = len(ids)
+		}
+		if err := db.leafRevisions(ctx, ids[start:end], revisions); err != nil {
+			return value.PurgeResult{}, fmt.Errorf("lookup: %w", err)
+		}
+	}
+	if len(revisions) == 0 {
+		return value.PurgeResult{Purged: map[string][]string{}}, nil
+	}
+	return db.Purge(ctx, revisions)
+}
+
+// PurgedInfosLimit fetches the number of purges, that are tracked by the database.
+func (db *Database) PurgedInfosLimit(ctx context.Context) (uint, error) {
+	r := uint(0)
+	if err := db.requestJSON(ctx, http.MethodGet, db.path("_purged_infos_limit"), nil, nil, &r); err != nil {
+		return 0, err
+	}
+	return r, nil
+}
+
+// SetPurgedInfosLimit sets the number of purges, that are tracked by the database.
+func (db *Database) SetPurgedInfosLimit(ctx context.Context, limit uint) error {
+	return db.requestJSON(ctx, http.MethodPut, db.path("_purged_infos_limit"), nil, limit, nil)
+}
+
+// leafRevisions adds the leaf revisions of the documents with the provided ids, including the tombstones,
+// to the provided map. A bulk get request without revisions returns all leaf revisions of each document.
+func (db *Database) leafRevisions(ctx context.Context, ids []string, revisions map[string][]string) error {
+	requests := make([]value.BulkGetRequest, len(ids))
+	for index, id := range ids {
+		requests[index] = value.BulkGetRequest{ID: id}
+	}
+
+	results, err := db.BulkGet(ctx, requests)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		for _, outcome := range result.Docs {
+			if outcome.OK == nil {
+				continue
+			}
+			meta := Meta{}
+			if err := json.Unmarshal(outcome.OK, &meta); err != nil {
+				return fmt.Errorf("decode %s: %w", result.ID, err)
+			}
+			revisions[result.ID] = append(revisions[result.ID], meta.Revision)
+		}
+	}
+	return nil
+}
+
+// purgeBatches splits the provided revisions into batches of at most `purgeMaxDocuments` document ids and
+// `purgeMaxRevisions` revisions. The revisions of a single document are split, if they exceed the limit.
+func purgeBatches(revisions map[string][]string) []map[string][]string {
+	ids := make([]string, 0, len(revisions))
+	for id := range revisions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	batches := []map[string][]string{}
+	batch, count := map[string][]string{}, 0
+	for _, id := range ids {
+		revs := revisions[id]
+		for len(revs) > 0 {
+			if len(batch) == purgeMaxDocuments || count == purgeMaxRevisions {
+				batches = append(batches, batch)
+				batch, count = map[string][]string{}, 0
+			}
+			n := purgeMaxRevisions - count
+			if n > len(revs) {
+				n = len(revs)
+			}
+			batch[id] = append(batch[id], revs[:n]...)
+			count += n
+			revs = revs[n:]
+		}
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
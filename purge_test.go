@@ -0,0 +1,109 @@
+package couchdb_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+)
+
+func TestPurge(t *testing.T) {
+	e := setUpTestEnvironment(t)
+	defer e.tearDown()
+
+	db := couchdb.NewDatabase(e.client, "test")
+	require.NoError(t, db.Create(e.ctx))
+	defer db.Delete(e.ctx)
+
+	t.Run("Purge", func(t *testing.T) {
+		document := couchdb.NewDocument(db, "one", "")
+		require.NoError(t, document.Store(e.ctx, map[string]interface{}{"test": "value"}))
+
+		result, err := db.Purge(e.ctx, map[string][]string{"one": {document.Revision()}})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string][]string{"one": {document.Revision()}}, result.Purged)
+
+		err = couchdb.NewDocument(db, "one", "").Fetch(e.ctx, nil)
+		assert.ErrorIs(t, err, couchdb.ErrNotFound)
+	})
+
+	t.Run("PurgeDocuments", func(t *testing.T) {
+		document := couchdb.NewDocument(db, "two", "")
+		require.NoError(t, document.Store(e.ctx, map[string]interface{}{"test": "value"}))
+		require.NoError(t, document.Delete(e.ctx))
+
+		result, err := db.PurgeDocuments(e.ctx, "two", "missing")
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string][]string{"two": {document.Revision()}}, result.Purged)
+	})
+
+	t.Run("PurgedInfosLimit", func(t *testing.T) {
+		require.NoError(t, db.SetPurgedInfosLimit(e.ctx, 500))
+
+		limit, err := db.PurgedInfosLimit(e.ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint(500), limit)
+	})
+}
+
+func TestPurgeDocumentsStub(t *testing.T) {
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/test/_bulk_get", jsonResponse(200, `{"results":[
+		{"id":"one","docs":[
+			{"ok":{"_id":"one","_rev":"2-b","test":"value"}},
+			{"ok":{"_id":"one","_rev":"3-c","_deleted":true}}]},
+		{"id":"missing","docs":[{"error":{"id":"missing","rev":"undefined","error":"not_found","reason":"missing"}}]}]}`))
+	httpClient.handle("http://a/test/_purge", jsonResponse(201, `{"purge_seq":null,"purged":{"one":["2-b","3-c"]}}`))
+
+	result, err := couchdb.NewDatabase(client, "test").PurgeDocuments(context.Background(), "one", "missing")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][]string{"one": {"2-b", "3-c"}}, result.Purged)
+	require.Len(t, httpClient.requests, 2)
+	assert.JSONEq(t, `{"docs":[{"id":"one"},{"id":"missing"}]}`, httpClient.requests[0].body)
+	assert.JSONEq(t, `{"one":["2-b","3-c"]}`, httpClient.requests[1].body)
+}
+
+func TestPurgeBatches(t *testing.T) {
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/test/_purge", func(request stubRequest) stubResponse {
+		batch := map[string][]string{}
+		require.NoError(t, json.Unmarshal([]byte(request.body), &batch))
+		body, err := json.Marshal(map[string]interface{}{"purge_seq": nil, "purged": batch})
+		require.NoError(t, err)
+		return stubResponse{statusCode: 201, body: string(body)}
+	})
+
+	revisions := map[string][]string{}
+	for index := 0; index < 150; index++ {
+		revisions[fmt.Sprintf("doc%03d", index)] = []string{"1-a"}
+	}
+	many := make([]string, 1500)
+	for index := range many {
+		many[index] = fmt.Sprintf("%d-a", index+1)
+	}
+	revisions["many"] = many
+
+	result, err := couchdb.NewDatabase(client, "test").Purge(context.Background(), revisions)
+	require.NoError(t, err)
+	assert.Equal(t, revisions, result.Purged)
+
+	for _, request := range httpClient.requests {
+		batch := map[string][]string{}
+		require.NoError(t, json.Unmarshal([]byte(request.body), &batch))
+		count := 0
+		for _, revs := range batch {
+			count += len(revs)
+		}
+		assert.LessOrEqual(t, len(batch), 100)
+		assert.LessOrEqual(t, count, 1000)
+	}
+	assert.Len(t, httpClient.requests, 3)
+}
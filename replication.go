@@ -0,0 +1,97 @@
+package couchdb
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+const replicatorDatabaseName = "_replicator"
+
+// NewReplicationEndpoint returns a replication endpoint for the provided url. If a username is provided,
+// the endpoint is authorized using basic auth.
+func NewReplicationEndpoint(url, username, password string) value.ReplicationEndpoint {
+	endpoint := value.ReplicationEndpoint{URL: url}
+	if username != "" {
+		endpoint.Headers = map[string]string{
+			"Authorization": "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password)),
+		}
+	}
+	return endpoint
+}
+
+// Replicate triggers a transient replication on the server. One-shot replications return after they
+// completed, continuous replications return right after they have been started.
+func (c *Client) Replicate(ctx context.Context, request value.ReplicationRequest) (value.ReplicationResult, error) {
+	r := value.ReplicationResult{}
+	if err := c.requestJSON(ctx, http.MethodPost, "/_replicate", nil, request, &r); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// CancelReplication cancels a running transient replication. The replication is either identified by the
+// replication id or by the parameters it has been started with.
+func (c *Client) CancelReplication(ctx context.Context, request value.ReplicationRequest) error {
+	request.Cancel = true
+	return c.requestJSON(ctx, http.MethodPost, "/_replicate", nil, request, nil)
+}
+
+// Replications returns all persistent replications of the `_replicator` database.
+func (c *Client) Replications(ctx context.Context) ([]value.ReplicationDocument, error) {
+	result, err := c.replicatorDatabase().AllDocs(ctx, WithIncludeDocs())
+	if err != nil {
+		return nil, err
+	}
+
+	documents := []value.ReplicationDocument{}
+	for _, row := range result.Rows {
+		if strings.HasPrefix(row.ID, designPrefix) || len(row.Doc) == 0 || string(row.Doc) == "null" {
+			continue
+		}
+		document := value.ReplicationDocument{}
+		if err := json.Unmarshal(row.Doc, &document); err != nil {
+			return nil, fmt.Errorf("json decode: %w", err)
+		}
+		documents = append(documents, document)
+	}
+	return documents, nil
+}
+
+// Replication returns the persistent replication with the provided id.
+func (c *Client) Replication(ctx context.Context, id string) (value.ReplicationDocument, error) {
+	document := value.ReplicationDocument{}
+	if err := NewDocument(c.replicatorDatabase(), id, "").Fetch(ctx, &document); err != nil {
+		return document, err
+	}
+	return document, nil
+}
+
+// CreateReplication stores the provided persistent replication in the `_replicator` database. If the
+// document has no id, the server generates one. The stored document is returned.
+func (c *Client) CreateReplication(ctx context.Context, replication value.ReplicationDocument) (*Document, error) {
+	document := NewDocument(c.replicatorDatabase(), replication.ID, "")
+	if err := document.Store(ctx, replication); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// DeleteReplication deletes the persistent replication with the provided id, which stops it. The current
+// revision is fetched first, because the server updates the document while the replication runs.
+func (c *Client) DeleteReplication(ctx context.Context, id string) error {
+	document := NewDocument(c.replicatorDatabase(), id, "")
+	if err := document.Fetch(ctx, nil); err != nil {
+		return err
+	}
+	return document.Delete(ctx)
+}
+
+func (c *Client) replicatorDatabase() *Database {
+	return NewDatabase(c, replicatorDatabaseName)
+}
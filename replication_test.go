@@ -0,0 +1,85 @@
+package couchdb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+	"github.com/simia-tech/couchdb/value"
+)
+
+func TestReplication(t *testing.T) {
+	e := setUpTestEnvironment(t)
+	defer e.tearDown()
+
+	source := couchdb.NewDatabase(e.client, "test")
+	require.NoError(t, source.Create(e.ctx))
+	defer source.Delete(e.ctx)
+
+	target := couchdb.NewDatabase(e.client, "test-target")
+	defer target.Delete(e.ctx)
+
+	require.NoError(t, couchdb.NewDocument(source, "test", "").Store(e.ctx, map[string]interface{}{"test": "value"}))
+
+	spec := value.ReplicationSpec{
+		Source:       couchdb.NewReplicationEndpoint("http://127.0.0.1:5984/test", "admin", "admin"),
+		Target:       couchdb.NewReplicationEndpoint("http://127.0.0.1:5984/test-target", "admin", "admin"),
+		CreateTarget: true,
+	}
+
+	t.Run("Replicate", func(t *testing.T) {
+		result, err := e.client.Replicate(e.ctx, value.ReplicationRequest{ReplicationSpec: spec})
+		require.NoError(t, err)
+
+		assert.True(t, result.OK)
+		require.NotEmpty(t, result.History)
+		assert.Equal(t, uint(1), result.History[0].DocsWritten)
+
+		require.NoError(t, couchdb.NewDocument(target, "test", "").Fetch(e.ctx, nil))
+	})
+
+	t.Run("ReplicateContinuousAndCancel", func(t *testing.T) {
+		continuous := spec
+		continuous.Continuous = true
+
+		result, err := e.client.Replicate(e.ctx, value.ReplicationRequest{ReplicationSpec: continuous})
+		require.NoError(t, err)
+		assert.NotEmpty(t, result.LocalID)
+
+		require.NoError(t, e.client.CancelReplication(e.ctx, value.ReplicationRequest{ReplicationSpec: continuous}))
+	})
+
+	t.Run("CancelMissing", func(t *testing.T) {
+		err := e.client.CancelReplication(e.ctx, value.ReplicationRequest{ReplicationID: "missing"})
+		assert.ErrorIs(t, err, couchdb.ErrNotFound)
+	})
+
+	t.Run("Persistent", func(t *testing.T) {
+		document, err := e.client.CreateReplication(e.ctx, value.ReplicationDocument{
+			ID:              "test",
+			ReplicationSpec: spec,
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, document.Revision())
+
+		replications, err := e.client.Replications(e.ctx)
+		require.NoError(t, err)
+		ids := []string{}
+		for _, replication := range replications {
+			ids = append(ids, replication.ID)
+		}
+		assert.Contains(t, ids, "test")
+
+		replication, err := e.client.Replication(e.ctx, "test")
+		require.NoError(t, err)
+		assert.Equal(t, spec.Source.URL, replication.Source.URL)
+		assert.True(t, replication.CreateTarget)
+
+		require.NoError(t, e.client.DeleteReplication(e.ctx, "test"))
+
+		_, err = e.client.Replication(e.ctx, "test")
+		assert.ErrorIs(t, err, couchdb.ErrNotFound)
+	})
+}
@@ -0,0 +1,394 @@
+package couchdb
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+// Various errors.
+var (
+	ErrReplicationIncomplete = errors.New("replication incomplete")
+)
+
+const (
+	replicationIDVersion       = 4
+	defaultReplicationBatch    = 100
+	defaultReplicationTimeout  = time.Minute
+	maxReplicationHistoryItems = 50
+)
+
+// Replicator implements the couchdb replication protocol between two databases, which can be reached
+// through different clients. The checkpoints are stored as local documents on both sides, so an interrupted
+// replication resumes where it stopped.
+type Replicator struct {
+	source       *Database
+	target       *Database
+	continuous   bool
+	createTarget bool
+	batchSize    uint
+	timeout      time.Duration
+	docIDs       []string
+	progress     func(value.ReplicationProgress)
+}
+
+// ReplicatorOption defines a function that can modify the replicator parameters.
+type ReplicatorOption func(*Replicator)
+
+// WithContinuous returns an option that keeps the replication running and replicates new changes as
+// they occur until the context is cancelled.
+func WithContinuous() ReplicatorOption {
+	return func(r *Replicator) {
+		r.continuous = true
+	}
+}
+
+// WithCreateTarget returns an option that creates the target database if it doesn't exist.
+func WithCreateTarget() ReplicatorOption {
+	return func(r *Replicator) {
+		r.createTarget = true
+	}
+}
+
+// WithBatchSize returns an option that sets the number of changes that are replicated per batch.
+func WithBatchSize(value uint) ReplicatorOption {
+	return func(r *Replicator) {
+		if value > 0 {
+			r.batchSize = value
+		}
+	}
+}
+
+// WithReplicationDocIDs returns an option that restricts the replication to the provided document ids.
+func WithReplicationDocIDs(ids ...string) ReplicatorOption {
+	return func(r *Replicator) {
+		r.docIDs = ids
+	}
+}
+
+// WithProgress returns an option that sets a function, which is called after each replicated batch.
+func WithProgress(fn func(value.ReplicationProgress)) ReplicatorOption {
+	return func(r *Replicator) {
+		r.progress = fn
+	}
+}
+
+// NewReplicator returns a new replicator from the source to the target database.
+func NewReplicator(source, target *Database, options ...ReplicatorOption) *Replicator {
+	r := &Replicator{
+		source:    source,
+		target:    target,
+		batchSize: defaultReplicationBatch,
+		timeout:   defaultReplicationTimeout,
+	}
+	for _, o := range options {
+		o(r)
+	}
+	return r
+}
+
+// ID returns the replication id, which identifies the checkpoints of the replication. The order of the
+// document ids doesn't affect the id.
+func (r *Replicator) ID() string {
+	docIDs := append([]string{}, r.docIDs...)
+	sort.Strings(docIDs)
+
+	hash := md5.New()
+	fmt.Fprintf(hash, "%s\n%s\n%s", r.source.url(), r.target.url(), strings.Join(docIDs, "\n"))
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Run performs the replication. In one-shot mode, it returns after all changes have been replicated. In
+// continuous mode, it runs until the context is cancelled. The returned progress holds the statistics of
+// the session.
+func (r *Replicator) Run(ctx context.Context) (value.ReplicationProgress, error) {
+	sessionID, err := newUUID()
+	if err != nil {
+		return value.ReplicationProgress{}, err
+	}
+	progress := value.ReplicationProgress{ReplicationID: r.ID(), SessionID: sessionID}
+
+	if err := r.verifyPeers(ctx); err != nil {
+		return progress, err
+	}
+
+	sourceLog, targetLog, err := r.readCheckpoints(ctx)
+	if err != nil {
+		return progress, err
+	}
+	since := commonSeq(sourceLog, targetLog)
+	progress.SourceLastSeq = since
+
+	session := &replicationSession{
+		replicator: r,
+		progress:   progress,
+		history: value.ReplicationHistory{
+			SessionID:    sessionID,
+			StartTime:    time.Now().UTC().Format(time.RFC1123),
+			StartLastSeq: since,
+		},
+		sourceLog: sourceLog,
+		targetLog: targetLog,
+	}
+
+	caughtUp := false
+	for {
+		feedType := FeedNormal
+		if caughtUp && r.continuous {
+			feedType = FeedLongpoll
+		}
+
+		changes, lastSeq, err := r.changes(ctx, feedType, session.progress.SourceLastSeq)
+		if err != nil {
+			if r.continuous && ctx.Err() != nil {
+				return session.progress, nil
+			}
+			return session.progress, err
+		}
+
+		if len(changes) > 0 {
+			if err := session.replicate(ctx, changes); err != nil {
+				return session.progress, err
+			}
+		}
+		if lastSeq != "" {
+			session.progress.SourceLastSeq = lastSeq
+		}
+		if len(changes) > 0 {
+			if err := session.checkpoint(ctx); err != nil {
+				return session.progress, err
+			}
+			if r.progress != nil {
+				r.progress(session.progress)
+			}
+		}
+
+		if len(changes) < int(r.batchSize) {
+			if !r.continuous {
+				return session.progress, nil
+			}
+			caughtUp = true
+		}
+	}
+}
+
+func (r *Replicator) verifyPeers(ctx context.Context) error {
+	if _, err := r.source.Info(ctx); err != nil {
+		return fmt.Errorf("source: %w", err)
+	}
+	_, err := r.target.Info(ctx)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, ErrDatabaseDoesNotExists) || !r.createTarget {
+		return fmt.Errorf("target: %w", err)
+	}
+	if err := r.target.Create(ctx); err != nil && !errors.Is(err, ErrDatabaseAlreadyExists) {
+		return fmt.Errorf("target: %w", err)
+	}
+	return nil
+}
+
+func (r *Replicator) readCheckpoints(ctx context.Context) (value.ReplicationLog, value.ReplicationLog, error) {
+	sourceLog, err := readReplicationLog(ctx, r.source, r.ID())
+	if err != nil {
+		return sourceLog, value.ReplicationLog{}, fmt.Errorf("source: %w", err)
+	}
+	targetLog, err := readReplicationLog(ctx, r.target, r.ID())
+	if err != nil {
+		return sourceLog, targetLog, fmt.Errorf("target: %w", err)
+	}
+	return sourceLog, targetLog, nil
+}
+
+func (r *Replicator) changes(ctx context.Context, feedType FeedType, since value.Seq) ([]value.Change, value.Seq, error) {
+	options := []FeedOption{
+		WithFeed(feedType),
+		WithStyleAllDocs(),
+		WithFeedLimit(r.batchSize),
+	}
+	if since != "" {
+		options = append(options, WithSince(since))
+	}
+	if feedType == FeedLongpoll {
+		options = append(options, WithTimeout(r.timeout))
+	}
+	if len(r.docIDs) > 0 {
+		options = append(options, WithDocIDs(r.docIDs...))
+	}
+
+	feed, err := r.source.Changes(ctx, options...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer feed.Close()
+
+	changes := []value.Change{}
+	for feed.Next() {
+		changes = append(changes, feed.Change())
+	}
+	if err := feed.Err(); err != nil {
+		return nil, "", err
+	}
+	return changes, feed.LastSeq(), nil
+}
+
+type replicationSession struct {
+	replicator *Replicator
+	progress   value.ReplicationProgress
+	history    value.ReplicationHistory
+	sourceLog  value.ReplicationLog
+	targetLog  value.ReplicationLog
+}
+
+// replicate transfers the revisions of the provided changes, that are missing on the target.
+func (s *replicationSession) replicate(ctx context.Context, changes []value.Change) error {
+	revisions := map[string][]string{}
+	for _, change := range changes {
+		for _, rev := range change.Changes {
+			revisions[change.ID] = append(revisions[change.ID], rev.Revision)
+			s.progress.MissingChecked++
+		}
+	}
+
+	diff, err := s.replicator.target.RevsDiff(ctx, revisions)
+	if err != nil {
+		return fmt.Errorf("target: %w", err)
+	}
+	if len(diff) == 0 {
+		return nil
+	}
+
+	requests := []value.BulkGetRequest{}
+	for id, d := range diff {
+		for _, revision := range d.Missing {
+			requests = append(requests, value.BulkGetRequest{ID: id, Revision: revision, AttsSince: d.PossibleAncestors})
+			s.progress.MissingFound++
+		}
+	}
+
+	results, err := s.replicator.source.BulkGet(ctx, requests, WithRevisions(), WithAttachments())
+	if err != nil {
+		return fmt.Errorf("source: %w", err)
+	}
+	docs := []interface{}{}
+	fetchFailures := uint(0)
+	for _, result := range results {
+		for _, outcome := range result.Docs {
+			if outcome.Error != nil || len(outcome.OK) == 0 {
+				fetchFailures++
+				continue
+			}
+			docs = append(docs, json.RawMessage(outcome.OK))
+			s.progress.DocsRead++
+		}
+	}
+
+	if len(docs) > 0 {
+		// Without new edits, only failed documents are reported.
+		writeResults, err := s.replicator.target.BulkDocs(ctx, docs, WithNewEdits(false))
+		if err != nil {
+			return fmt.Errorf("target: %w", err)
+		}
+		failures := uint(0)
+		for _, result := range writeResults {
+			if result.Error != "" {
+				failures++
+			}
+		}
+		s.progress.DocsWritten += uint(len(docs)) - failures
+		s.progress.DocWriteFailures += failures
+	}
+
+	// Revisions that couldn't be fetched must not be skipped by the checkpoint, so they are retried by the
+	// next run.
+	if fetchFailures > 0 {
+		s.progress.DocWriteFailures += fetchFailures
+		return fmt.Errorf("source: %w: %d revisions could not be fetched", ErrReplicationIncomplete, fetchFailures)
+	}
+	return nil
+}
+
+// checkpoint records the current progress on the source and the target.
+func (s *replicationSession) checkpoint(ctx context.Context) error {
+	s.history.EndTime = time.Now().UTC().Format(time.RFC1123)
+	s.history.EndLastSeq = s.progress.SourceLastSeq
+	s.history.RecordedSeq = s.progress.SourceLastSeq
+	s.history.MissingChecked = s.progress.MissingChecked
+	s.history.MissingFound = s.progress.MissingFound
+	s.history.DocsRead = s.progress.DocsRead
+	s.history.DocsWritten = s.progress.DocsWritten
+	s.history.DocWriteFailures = s.progress.DocWriteFailures
+
+	id := s.replicator.ID()
+	var err error
+	if s.sourceLog, err = writeReplicationLog(ctx, s.replicator.source, id, s.sourceLog, s.history); err != nil {
+		return fmt.Errorf("source: %w", err)
+	}
+	if s.targetLog, err = writeReplicationLog(ctx, s.replicator.target, id, s.targetLog, s.history); err != nil {
+		return fmt.Errorf("target: %w", err)
+	}
+	return nil
+}
+
+func readReplicationLog(ctx context.Context, db *Database, id string) (value.ReplicationLog, error) {
+	log := value.ReplicationLog{}
+	if err := NewLocalDocument(db, id, "").Fetch(ctx, &log); err != nil && !errors.Is(err, ErrNotFound) {
+		return log, err
+	}
+	return log, nil
+}
+
+func writeReplicationLog(
+	ctx context.Context,
+	db *Database,
+	id string,
+	log value.ReplicationLog,
+	history value.ReplicationHistory,
+) (value.ReplicationLog, error) {
+	if len(log.History) > 0 && log.History[0].SessionID == history.SessionID {
+		log.History[0] = history
+	} else {
+		log.History = append([]value.ReplicationHistory{history}, log.History...)
+	}
+	if len(log.History) > maxReplicationHistoryItems {
+		log.History = log.History[:maxReplicationHistoryItems]
+	}
+	log.SessionID = history.SessionID
+	log.SourceLastSeq = history.RecordedSeq
+	log.ReplicationIDVersion = replicationIDVersion
+
+	document := NewLocalDocument(db, id, log.Revision)
+	if err := document.Store(ctx, log); err != nil {
+		return log, err
+	}
+	log.Revision = document.Revision()
+	return log, nil
+}
+
+// commonSeq returns the sequence from which a replication can resume, based on the checkpoints of the
+// source and the target.
+func commonSeq(sourceLog, targetLog value.ReplicationLog) value.Seq {
+	if sourceLog.SessionID == "" || targetLog.SessionID == "" {
+		return ""
+	}
+	if sourceLog.SessionID == targetLog.SessionID {
+		return sourceLog.SourceLastSeq
+	}
+	for _, sourceHistory := range sourceLog.History {
+		for _, targetHistory := range targetLog.History {
+			if sourceHistory.SessionID == targetHistory.SessionID {
+				return sourceHistory.RecordedSeq
+			}
+		}
+	}
+	return ""
+}
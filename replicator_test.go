@@ -0,0 +1,124 @@
+package couchdb_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+	"github.com/simia-tech/couchdb/value"
+)
+
+func TestReplicator(t *testing.T) {
+	e := setUpTestEnvironment(t)
+	defer e.tearDown()
+
+	source := couchdb.NewDatabase(e.client, "test")
+	require.NoError(t, source.Create(e.ctx))
+	defer source.Delete(e.ctx)
+
+	target := couchdb.NewDatabase(e.client, "test-target")
+	defer target.Delete(e.ctx)
+
+	document := couchdb.NewDocument(source, "test", "")
+	require.NoError(t, document.Store(e.ctx, map[string]interface{}{"test": "value"}))
+	require.NoError(t, document.PutAttachment(e.ctx, "test.txt", "text/plain", strings.NewReader("test")))
+
+	t.Run("OneShot", func(t *testing.T) {
+		reports := []value.ReplicationProgress{}
+		replicator := couchdb.NewReplicator(source, target,
+			couchdb.WithCreateTarget(),
+			couchdb.WithProgress(func(progress value.ReplicationProgress) { reports = append(reports, progress) }))
+
+		progress, err := replicator.Run(e.ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, replicator.ID(), progress.ReplicationID)
+		assert.Equal(t, uint(1), progress.DocsWritten)
+		assert.Len(t, reports, 1)
+
+		replicated := couchdb.NewDocument(target, "test", "")
+		data := map[string]interface{}{}
+		require.NoError(t, replicated.Fetch(e.ctx, &data))
+		assert.Equal(t, document.Revision(), replicated.Revision())
+		assert.Equal(t, "value", data["test"])
+
+		reader, _, err := replicated.Attachment(e.ctx, "test.txt")
+		require.NoError(t, err)
+		content, err := io.ReadAll(reader)
+		require.NoError(t, reader.Close())
+		require.NoError(t, err)
+		assert.Equal(t, "test", string(content))
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		progress, err := couchdb.NewReplicator(source, target).Run(e.ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint(0), progress.MissingChecked)
+		assert.Equal(t, uint(0), progress.DocsWritten)
+	})
+
+	t.Run("Continuous", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(e.ctx)
+		defer cancel()
+
+		done := make(chan error)
+		go func() {
+			_, err := couchdb.NewReplicator(source, target, couchdb.WithContinuous()).Run(ctx)
+			done <- err
+		}()
+
+		require.NoError(t, couchdb.NewDocument(source, "another", "").Store(e.ctx, map[string]interface{}{"test": "value"}))
+
+		assert.Eventually(t, func() bool {
+			return couchdb.NewDocument(target, "another", "").Fetch(e.ctx, &map[string]interface{}{}) == nil
+		}, 5*time.Second, 100*time.Millisecond)
+
+		cancel()
+		require.NoError(t, <-done)
+	})
+}
+
+func TestReplicatorStub(t *testing.T) {
+	t.Run("FetchFailure", func(t *testing.T) {
+		client, httpClient := newStubClient(t)
+		httpClient.handle("http://a/source", jsonResponse(200, `{"db_name":"source"}`))
+		httpClient.handle("http://a/target", jsonResponse(200, `{"db_name":"target"}`))
+		httpClient.handle("http://a/source/_changes", jsonResponse(200,
+			`{"results":[{"seq":"2-a","id":"one","changes":[{"rev":"1-a"}]},{"seq":"3-a","id":"two","changes":[{"rev":"1-b"}]}],"last_seq":"3-a"}`))
+		httpClient.handle("http://a/target/_revs_diff", jsonResponse(200,
+			`{"one":{"missing":["1-a"]},"two":{"missing":["1-b"]}}`))
+		httpClient.handle("http://a/source/_bulk_get", jsonResponse(200, `{"results":[`+
+			`{"id":"one","docs":[{"ok":{"_id":"one","_rev":"1-a"}}]},`+
+			`{"id":"two","docs":[{"error":{"id":"two","rev":"1-b","error":"not_found","reason":"missing"}}]}]}`))
+		httpClient.handle("http://a/target/_bulk_docs", jsonResponse(201, `[]`))
+
+		source, target := couchdb.NewDatabase(client, "source"), couchdb.NewDatabase(client, "target")
+		progress, err := couchdb.NewReplicator(source, target).Run(context.Background())
+		assert.ErrorIs(t, err, couchdb.ErrReplicationIncomplete)
+
+		assert.Equal(t, uint(1), progress.DocsWritten)
+		assert.Equal(t, uint(1), progress.DocWriteFailures)
+		assert.Contains(t, httpClient.urls(), "http://a/target/_bulk_docs")
+		for _, request := range httpClient.requests {
+			assert.False(t, request.method == http.MethodPut && strings.Contains(request.url, "_local"),
+				"unexpected checkpoint %s", request.url)
+		}
+	})
+
+	t.Run("IDIgnoresDocIDOrder", func(t *testing.T) {
+		client, _ := newStubClient(t)
+
+		source, target := couchdb.NewDatabase(client, "source"), couchdb.NewDatabase(client, "target")
+		assert.Equal(t,
+			couchdb.NewReplicator(source, target, couchdb.WithReplicationDocIDs("one", "two")).ID(),
+			couchdb.NewReplicator(source, target, couchdb.WithReplicationDocIDs("two", "one")).ID())
+	})
+}
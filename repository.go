@@ -0,0 +1,247 @@
+package couchdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+// Various errors.
+var (
+	ErrUnsupportedIDStrategy = errors.New("unsupported id strategy")
+)
+
+const (
+	defaultTypeField = "type"
+	maxCollationKey  = "\ufff0"
+	countPageSize    = 1000
+)
+
+// Repository implements typed CRUD operations and queries for the entities of one type. Each stored
+// entity carries a type discriminator field, so several types can share a database.
+type Repository[T any] struct {
+	database   *Database
+	typeName   string
+	typeField  string
+	idStrategy IDStrategy[T]
+}
+
+// RepositoryOption defines a function that can modify the repository parameters.
+type RepositoryOption[T any] func(*Repository[T])
+
+// WithIDStrategy returns an option that sets the strategy that generates the ids of new entities. It
+// defaults to `TypePrefixedStrategy`.
+func WithIDStrategy[T any](value IDStrategy[T]) RepositoryOption[T] {
+	return func(r *Repository[T]) {
+		r.idStrategy = value
+	}
+}
+
+// WithTypeField returns an option that sets the name of the type discriminator field. It defaults to `type`.
+func WithTypeField[T any](value string) RepositoryOption[T] {
+	return func(r *Repository[T]) {
+		r.typeField = value
+	}
+}
+
+// NewRepository returns a new repository for the entities of the provided type name.
+func NewRepository[T any](database *Database, typeName string, options ...RepositoryOption[T]) *Repository[T] {
+	r := &Repository[T]{
+		database:   database,
+		typeName:   typeName,
+		typeField:  defaultTypeField,
+		idStrategy: TypePrefixedStrategy[T](),
+	}
+	for _, o := range options {
+		o(r)
+	}
+	return r
+}
+
+// Get fetches the entity with the provided id. If the document has another type, `ErrNotFound` is returned.
+func (r *Repository[T]) Get(ctx context.Context, id string) (*T, error) {
+	data := json.RawMessage{}
+	if err := NewDocument(r.database, id, "").Fetch(ctx, &data); err != nil {
+		return nil, err
+	}
+	return r.decode(id, data)
+}
+
+// Put stores the provided entity. If it has no id yet, one is generated using the id strategy. The id and
+// the new revision are written back to the entity.
+func (r *Repository[T]) Put(ctx context.Context, entity *T) error {
+	td := NewTypedDocument(r.database, entity)
+	if td.err != nil {
+		return td.err
+	}
+	if td.ID() == "" {
+		id, err := r.idStrategy.ID(r.typeName, entity)
+		if err != nil {
+			return err
+		}
+		td.setID(id)
+	}
+
+	data, err := r.encode(entity)
+	if err != nil {
+		return err
+	}
+
+	return td.store(ctx, data)
+}
+
+// Delete deletes the provided entity.
+func (r *Repository[T]) Delete(ctx context.Context, entity *T) error {
+	return NewTypedDocument(r.database, entity).Delete(ctx)
+}
+
+// List fetches the entities ordered by their ids using `_all_docs`. It requires an id strategy that
+// prefixes the ids with the type name.
+func (r *Repository[T]) List(ctx context.Context, options ...ViewOption) ([]*T, error) {
+	result, err := r.allDocs(ctx, append(append([]ViewOption{}, options...), WithIncludeDocs()))
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]*T, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		if len(row.Doc) == 0 || string(row.Doc) == "null" {
+			continue
+		}
+		entity, err := r.decode(row.ID, row.Doc)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, entity)
+	}
+
+	return entities, nil
+}
+
+// FindBy fetches the entities that match the provided mango query. The selector is combined with the
+// type discriminator.
+func (r *Repository[T]) FindBy(ctx context.Context, query value.FindQuery) ([]*T, error) {
+	query.Selector = r.selector(query.Selector)
+
+	result, err := r.database.Find(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]*T, 0, len(result.Docs))
+	for _, doc := range result.Docs {
+		entity, err := r.decode("", doc)
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, entity)
+	}
+
+	return entities, nil
+}
+
+// Count returns the number of entities, that would be returned by `List`. Only the ids of the matching
+// documents are fetched. It requires an id strategy that prefixes the ids with the type name.
+func (r *Repository[T]) Count(ctx context.Context) (int, error) {
+	prefix := r.idStrategy.Prefix(r.typeName)
+	if prefix == "" {
+		return 0, fmt.Errorf("count %s: %w", r.typeName, ErrUnsupportedIDStrategy)
+	}
+
+	query := value.FindQuery{
+		Selector: r.selector(map[string]interface{}{
+			metaID: map[string]interface{}{"$gte": prefix, "$lt": prefix + maxCollationKey},
+		}),
+		Fields: []string{metaID},
+		Limit:  countPageSize,
+	}
+	count := 0
+	for {
+		result, err := r.database.Find(ctx, query)
+		if err != nil {
+			return 0, err
+		}
+		count += len(result.Docs)
+		if len(result.Docs) < countPageSize {
+			return count, nil
+		}
+		query.Bookmark = result.Bookmark
+	}
+}
+
+func (r *Repository[T]) allDocs(ctx context.Context, options []ViewOption) (value.ViewResult, error) {
+	prefix := r.idStrategy.Prefix(r.typeName)
+	if prefix == "" {
+		return value.ViewResult{}, fmt.Errorf("list %s: %w", r.typeName, ErrUnsupportedIDStrategy)
+	}
+
+	query, err := viewQuery(options)
+	if err != nil {
+		return value.ViewResult{}, err
+	}
+	startKey, endKey := prefix, prefix+maxCollationKey
+	if query.Get("descending") == "true" {
+		startKey, endKey = endKey, startKey
+	}
+
+	return r.database.AllDocs(ctx, append(append([]ViewOption{}, options...), WithStartKey(startKey), WithEndKey(endKey))...)
+}
+
+func (r *Repository[T]) selector(selector interface{}) interface{} {
+	typeSelector := map[string]interface{}{r.typeField: r.typeName}
+	if selector == nil {
+		return typeSelector
+	}
+	return map[string]interface{}{"$and": []interface{}{typeSelector, selector}}
+}
+
+// encode returns the encoded entity including the type discriminator.
+func (r *Repository[T]) encode(entity *T) (json.RawMessage, error) {
+	codec := r.database.client.codec
+
+	buffer := &bytes.Buffer{}
+	if err := codec.Encode(buffer, entity); err != nil {
+		return nil, fmt.Errorf("encode: %w", err)
+	}
+	fields := map[string]json.RawMessage{}
+	if err := codec.Decode(buffer, &fields); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+	typeName, err := json.Marshal(r.typeName)
+	if err != nil {
+		return nil, fmt.Errorf("json encode: %w", err)
+	}
+	fields[r.typeField] = typeName
+
+	buffer.Reset()
+	if err := codec.Encode(buffer, fields); err != nil {
+		return nil, fmt.Errorf("encode: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// decode returns the entity from the provided encoded document after the type discriminator has been checked.
+func (r *Repository[T]) decode(id string, data json.RawMessage) (*T, error) {
+	codec := r.database.client.codec
+
+	discriminator := map[string]interface{}{}
+	if err := codec.Decode(bytes.NewReader(data), &discriminator); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+	if discriminator[r.typeField] != r.typeName {
+		return nil, fmt.Errorf("%s %s: %w", r.typeName, id, ErrNotFound)
+	}
+
+	entity := new(T)
+	if err := codec.Decode(bytes.NewReader(data), entity); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+	return entity, nil
+}
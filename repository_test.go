@@ -0,0 +1,144 @@
+package couchdb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+	"github.com/simia-tech/couchdb/value"
+)
+
+type testOrder struct {
+	couchdb.Meta
+	Number string `json:"number"`
+	Amount int    `json:"amount"`
+}
+
+func TestRepository(t *testing.T) {
+	e := setUpTestEnvironment(t)
+	defer e.tearDown()
+
+	db := couchdb.NewDatabase(e.client, "test")
+	require.NoError(t, db.Create(e.ctx))
+	defer db.Delete(e.ctx)
+
+	orders := couchdb.NewRepository[testOrder](db, "order")
+	users := couchdb.NewRepository[testOrder](db, "user")
+
+	first := &testOrder{Number: "1", Amount: 10}
+	require.NoError(t, orders.Put(e.ctx, first))
+	second := &testOrder{Number: "2", Amount: 20}
+	require.NoError(t, orders.Put(e.ctx, second))
+	require.NoError(t, users.Put(e.ctx, &testOrder{Number: "3"}))
+
+	t.Run("Put", func(t *testing.T) {
+		assert.Regexp(t, `^order:[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, first.ID)
+		assert.Regexp(t, `^1\-[0-9a-f]+$`, first.Revision)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		order, err := orders.Get(e.ctx, first.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, first, order)
+	})
+
+	t.Run("GetOtherType", func(t *testing.T) {
+		_, err := users.Get(e.ctx, first.ID)
+		assert.ErrorIs(t, err, couchdb.ErrNotFound)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		list, err := orders.List(e.ctx)
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []*testOrder{first, second}, list)
+	})
+
+	t.Run("FindBy", func(t *testing.T) {
+		list, err := orders.FindBy(e.ctx, value.FindQuery{
+			Selector: map[string]interface{}{"amount": map[string]interface{}{"$gt": 15}},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []*testOrder{second}, list)
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		count, err := orders.Count(e.ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("NaturalKey", func(t *testing.T) {
+		numbered := couchdb.NewRepository(db, "numbered",
+			couchdb.WithIDStrategy(couchdb.NaturalKeyStrategy(func(o *testOrder) string { return o.Number })))
+
+		order := &testOrder{Number: "42"}
+		require.NoError(t, numbered.Put(e.ctx, order))
+
+		assert.Equal(t, "numbered:42", order.ID)
+	})
+
+	t.Run("UUIDList", func(t *testing.T) {
+		unprefixed := couchdb.NewRepository(db, "order", couchdb.WithIDStrategy(couchdb.UUIDStrategy[testOrder]()))
+
+		_, err := unprefixed.List(e.ctx)
+		assert.ErrorIs(t, err, couchdb.ErrUnsupportedIDStrategy)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		order := &testOrder{Number: "4"}
+		require.NoError(t, orders.Put(e.ctx, order))
+
+		require.NoError(t, orders.Delete(e.ctx, order))
+
+		_, err := orders.Get(e.ctx, order.ID)
+		assert.ErrorIs(t, err, couchdb.ErrNotFound)
+	})
+}
+
+func TestRepositoryCountStub(t *testing.T) {
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/test/_find", jsonResponse(200, `{"docs":[{"_id":"order:a"}],"bookmark":"b"}`))
+
+	count, err := couchdb.NewRepository[testOrder](couchdb.NewDatabase(client, "test"), "order").Count(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, count)
+	require.Len(t, httpClient.requests, 1)
+	assert.JSONEq(t, `{
+		"selector": {"$and": [{"type": "order"}, {"_id": {"$gte": "order:", "$lt": "order:\ufff0"}}]},
+		"fields": ["_id"],
+		"limit": 1000
+	}`, httpClient.requests[0].body)
+}
+
+func TestRepositoryListKeepsOptions(t *testing.T) {
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/test/_all_docs", jsonResponse(200, `{"rows":[]}`))
+
+	options := make([]couchdb.ViewOption, 1, 4)
+	options[0] = couchdb.WithLimit(1)
+	backing := options[:4]
+	backing[1] = nil
+
+	_, err := couchdb.NewRepository[testOrder](couchdb.NewDatabase(client, "test"), "order").List(context.Background(), options...)
+	require.NoError(t, err)
+
+	assert.Nil(t, backing[1])
+}
+
+func TestUUIDStrategy(t *testing.T) {
+	id, err := couchdb.UUIDStrategy[testOrder]().ID("order", &testOrder{})
+	require.NoError(t, err)
+	assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, id)
+
+	id, err = couchdb.TypePrefixedStrategy[testOrder]().ID("order", &testOrder{})
+	require.NoError(t, err)
+	assert.Regexp(t, `^order:[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, id)
+}
@@ -0,0 +1,123 @@
+package couchdb
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Various errors.
+var (
+	ErrInvalidRevision = errors.New("invalid revision")
+)
+
+// Rev holds a document revision that consists of a generation number and a hash.
+type Rev struct {
+	generation uint64
+	hash       string
+}
+
+// NewRev returns a new revision with the provided generation and hash.
+func NewRev(generation uint64, hash string) Rev {
+	return Rev{generation: generation, hash: hash}
+}
+
+// ParseRev parses the provided revision string, e.g. `3-917fa2381192822767f010b95b45325b`.
+func ParseRev(value string) (Rev, error) {
+	generation, hash, ok := strings.Cut(value, "-")
+	if !ok || hash == "" {
+		return Rev{}, fmt.Errorf("%w: %q", ErrInvalidRevision, value)
+	}
+	g, err := strconv.ParseUint(generation, 10, 64)
+	if err != nil || g == 0 {
+		return Rev{}, fmt.Errorf("%w: %q", ErrInvalidRevision, value)
+	}
+	return Rev{generation: g, hash: hash}, nil
+}
+
+// Generation returns the revision's generation number.
+func (r Rev) Generation() uint64 {
+	return r.generation
+}
+
+// Hash returns the revision's hash.
+func (r Rev) Hash() string {
+	return r.hash
+}
+
+// IsZero returns true if the revision is empty.
+func (r Rev) IsZero() bool {
+	return r.generation == 0 && r.hash == ""
+}
+
+// String returns the revision string.
+func (r Rev) String() string {
+	if r.IsZero() {
+		return ""
+	}
+	return strconv.FormatUint(r.generation, 10) + "-" + r.hash
+}
+
+// Compare returns -1, 0 or 1 if the revision is lower, equal or higher than the other one. Revisions
+// are ordered by generation first and by hash second, like couchdb does to pick the winning revision.
+func (r Rev) Compare(other Rev) int {
+	switch {
+	case r.generation < other.generation:
+		return -1
+	case r.generation > other.generation:
+		return 1
+	}
+	return strings.Compare(r.hash, other.hash)
+}
+
+// Less returns true if the revision is lower than the other one.
+func (r Rev) Less(other Rev) bool {
+	return r.Compare(other) < 0
+}
+
+// MarshalText implements `encoding.TextMarshaler`.
+func (r Rev) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements `encoding.TextUnmarshaler`.
+func (r *Rev) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*r = Rev{}
+		return nil
+	}
+	rev, err := ParseRev(string(text))
+	if err != nil {
+		return err
+	}
+	*r = rev
+	return nil
+}
+
+// Leaf holds a leaf revision of a revision tree.
+type Leaf struct {
+	Rev     Rev
+	Deleted bool
+}
+
+// WinningRev returns the revision that couchdb picks as the winner among the provided leaves. Leaves
+// that are not deleted win over deleted ones, otherwise the highest revision wins.
+func WinningRev(leaves []Leaf) (Rev, bool) {
+	if len(leaves) == 0 {
+		return Rev{}, false
+	}
+	winner := leaves[0]
+	for _, leaf := range leaves[1:] {
+		if winner.Deleted != leaf.Deleted {
+			if winner.Deleted {
+				winner = leaf
+			}
+			continue
+		}
+		if winner.Rev.Less(leaf.Rev) {
+			winner = leaf
+		}
+	}
+	return winner.Rev, true
+}
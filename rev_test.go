@@ -0,0 +1,128 @@
+package couchdb_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+	"github.com/simia-tech/couchdb/value"
+)
+
+func TestRev(t *testing.T) {
+	t.Run("Parse", func(t *testing.T) {
+		rev, err := couchdb.ParseRev("3-917fa2381192822767f010b95b45325b")
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(3), rev.Generation())
+		assert.Equal(t, "917fa2381192822767f010b95b45325b", rev.Hash())
+		assert.Equal(t, "3-917fa2381192822767f010b95b45325b", rev.String())
+	})
+
+	t.Run("ParseInvalid", func(t *testing.T) {
+		for _, value := range []string{"", "malformed", "0-abc", "x-abc", "1-", "-abc"} {
+			_, err := couchdb.ParseRev(value)
+			assert.ErrorIs(t, err, couchdb.ErrInvalidRevision, value)
+		}
+	})
+
+	t.Run("Compare", func(t *testing.T) {
+		assert.Equal(t, -1, mustParseRev(t, "1-b").Compare(mustParseRev(t, "2-a")))
+		assert.Equal(t, 1, mustParseRev(t, "10-a").Compare(mustParseRev(t, "9-b")))
+		assert.Equal(t, -1, mustParseRev(t, "2-a").Compare(mustParseRev(t, "2-b")))
+		assert.Equal(t, 0, mustParseRev(t, "2-a").Compare(mustParseRev(t, "2-a")))
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		data := struct {
+			Rev couchdb.Rev `json:"rev"`
+		}{}
+		require.NoError(t, json.Unmarshal([]byte(`{"rev":"2-abc"}`), &data))
+		assert.Equal(t, couchdb.NewRev(2, "abc"), data.Rev)
+
+		encoded, err := json.Marshal(data)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"rev":"2-abc"}`, string(encoded))
+	})
+
+	t.Run("WinningRev", func(t *testing.T) {
+		winner, ok := couchdb.WinningRev([]couchdb.Leaf{
+			{Rev: mustParseRev(t, "2-a")},
+			{Rev: mustParseRev(t, "2-c")},
+			{Rev: mustParseRev(t, "3-b"), Deleted: true},
+			{Rev: mustParseRev(t, "1-z")},
+		})
+		require.True(t, ok)
+		assert.Equal(t, "2-c", winner.String())
+
+		winner, ok = couchdb.WinningRev([]couchdb.Leaf{
+			{Rev: mustParseRev(t, "2-a"), Deleted: true},
+			{Rev: mustParseRev(t, "3-b"), Deleted: true},
+		})
+		require.True(t, ok)
+		assert.Equal(t, "3-b", winner.String())
+
+		_, ok = couchdb.WinningRev(nil)
+		assert.False(t, ok)
+	})
+}
+
+func TestRevTree(t *testing.T) {
+	tree := couchdb.NewRevTree()
+	require.NoError(t, tree.AddRevisions(value.Revisions{Start: 3, IDs: []string{"c", "b", "a"}}, false))
+	require.NoError(t, tree.AddRevisions(value.Revisions{Start: 3, IDs: []string{"d", "b", "a"}}, false))
+	require.NoError(t, tree.AddRevsInfo([]value.RevInfo{
+		{Revision: "4-e", Status: value.RevStatusDeleted},
+		{Revision: "3-f", Status: value.RevStatusAvailable},
+		{Revision: "2-b", Status: value.RevStatusMissing},
+	}))
+
+	t.Run("Contains", func(t *testing.T) {
+		assert.True(t, tree.Contains(mustParseRev(t, "2-b")))
+		assert.False(t, tree.Contains(mustParseRev(t, "2-x")))
+	})
+
+	t.Run("IsAncestor", func(t *testing.T) {
+		assert.True(t, tree.IsAncestor(mustParseRev(t, "1-a"), mustParseRev(t, "3-c")))
+		assert.True(t, tree.IsAncestor(mustParseRev(t, "2-b"), mustParseRev(t, "4-e")))
+		assert.False(t, tree.IsAncestor(mustParseRev(t, "3-c"), mustParseRev(t, "3-d")))
+		assert.False(t, tree.IsAncestor(mustParseRev(t, "3-c"), mustParseRev(t, "1-a")))
+	})
+
+	t.Run("Leaves", func(t *testing.T) {
+		assert.Equal(t, []couchdb.Leaf{
+			{Rev: mustParseRev(t, "3-d")},
+			{Rev: mustParseRev(t, "3-c")},
+			{Rev: mustParseRev(t, "4-e"), Deleted: true},
+		}, tree.Leaves())
+	})
+
+	t.Run("Winner", func(t *testing.T) {
+		winner, ok := tree.Winner()
+		require.True(t, ok)
+		assert.Equal(t, "3-d", winner.String())
+	})
+
+	t.Run("Diff", func(t *testing.T) {
+		missing, possibleAncestors := tree.Diff([]couchdb.Rev{
+			mustParseRev(t, "3-c"),
+			mustParseRev(t, "4-x"),
+		})
+
+		assert.Equal(t, []couchdb.Rev{mustParseRev(t, "4-x")}, missing)
+		assert.Equal(t, []couchdb.Rev{mustParseRev(t, "3-d"), mustParseRev(t, "3-c")}, possibleAncestors)
+	})
+
+	t.Run("InvalidRevisions", func(t *testing.T) {
+		err := couchdb.NewRevTree().AddRevisions(value.Revisions{Start: 1, IDs: []string{"b", "a"}}, false)
+		assert.ErrorIs(t, err, couchdb.ErrInvalidRevision)
+	})
+}
+
+func mustParseRev(tb testing.TB, value string) couchdb.Rev {
+	rev, err := couchdb.ParseRev(value)
+	require.NoError(tb, err)
+	return rev
+}
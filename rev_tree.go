@@ -0,0 +1,161 @@
+package couchdb
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+// RevTree models the revision tree of a document. It's built from the `_revisions` and `_revs_info`
+// fields and might be incomplete, if the history has been pruned.
+type RevTree struct {
+	nodes map[Rev]*revNode
+}
+
+type revNode struct {
+	parent   Rev
+	children map[Rev]bool
+	status   string
+}
+
+// NewRevTree returns a new empty revision tree.
+func NewRevTree() *RevTree {
+	return &RevTree{nodes: map[Rev]*revNode{}}
+}
+
+// AddRevisions adds the revision path of the provided `_revisions` field to the tree. If deleted is true,
+// the newest revision of the path is marked as deleted.
+func (t *RevTree) AddRevisions(revisions value.Revisions, deleted bool) error {
+	if len(revisions.IDs) == 0 {
+		return nil
+	}
+	if uint64(len(revisions.IDs)) > revisions.Start {
+		return fmt.Errorf("%w: %d ids exceed start %d", ErrInvalidRevision, len(revisions.IDs), revisions.Start)
+	}
+
+	path := make([]Rev, len(revisions.IDs))
+	for index, id := range revisions.IDs {
+		path[index] = NewRev(revisions.Start-uint64(index), id)
+	}
+	t.addPath(path)
+
+	if deleted {
+		t.nodes[path[0]].status = value.RevStatusDeleted
+	}
+	return nil
+}
+
+// AddRevsInfo adds the revision path of the provided `_revs_info` field to the tree. The infos are expected
+// from the newest to the oldest revision.
+func (t *RevTree) AddRevsInfo(infos []value.RevInfo) error {
+	path := make([]Rev, len(infos))
+	for index, info := range infos {
+		rev, err := ParseRev(info.Revision)
+		if err != nil {
+			return err
+		}
+		if index > 0 && rev.Generation()+1 != path[index-1].Generation() {
+			return fmt.Errorf("%w: %s doesn't precede %s", ErrInvalidRevision, rev, path[index-1])
+		}
+		path[index] = rev
+	}
+	t.addPath(path)
+
+	for index, info := range infos {
+		t.nodes[path[index]].status = info.Status
+	}
+	return nil
+}
+
+// addPath adds the provided path, which is ordered from the newest to the oldest revision.
+func (t *RevTree) addPath(path []Rev) {
+	for index := len(path) - 1; index >= 0; index-- {
+		node := t.node(path[index])
+		if index < len(path)-1 {
+			node.parent = path[index+1]
+			t.node(path[index+1]).children[path[index]] = true
+		}
+	}
+}
+
+func (t *RevTree) node(rev Rev) *revNode {
+	node, ok := t.nodes[rev]
+	if !ok {
+		node = &revNode{children: map[Rev]bool{}, status: value.RevStatusAvailable}
+		t.nodes[rev] = node
+	}
+	return node
+}
+
+// Contains returns true if the tree contains the provided revision.
+func (t *RevTree) Contains(rev Rev) bool {
+	_, ok := t.nodes[rev]
+	return ok
+}
+
+// Parent returns the parent of the provided revision. If the parent is unknown, false is returned.
+func (t *RevTree) Parent(rev Rev) (Rev, bool) {
+	node, ok := t.nodes[rev]
+	if !ok || node.parent.IsZero() {
+		return Rev{}, false
+	}
+	return node.parent, true
+}
+
+// IsAncestor returns true if the provided ancestor is a (transitive) parent of the provided descendant.
+func (t *RevTree) IsAncestor(ancestor, descendant Rev) bool {
+	for rev, ok := t.Parent(descendant); ok; rev, ok = t.Parent(rev) {
+		if rev == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// Leaves returns the leaf revisions of the tree ordered from the winning to the lowest revision.
+func (t *RevTree) Leaves() []Leaf {
+	leaves := []Leaf{}
+	for rev, node := range t.nodes {
+		if len(node.children) == 0 {
+			leaves = append(leaves, Leaf{Rev: rev, Deleted: node.status == value.RevStatusDeleted})
+		}
+	}
+	sort.Slice(leaves, func(i, j int) bool {
+		if leaves[i].Deleted != leaves[j].Deleted {
+			return !leaves[i].Deleted
+		}
+		return leaves[j].Rev.Less(leaves[i].Rev)
+	})
+	return leaves
+}
+
+// Winner returns the winning revision of the tree.
+func (t *RevTree) Winner() (Rev, bool) {
+	return WinningRev(t.Leaves())
+}
+
+// Diff returns the provided revisions, that are missing in the tree, and the leaves of the tree, that might
+// be ancestors of the missing revisions, like couchdb's `_revs_diff` does.
+func (t *RevTree) Diff(revs []Rev) ([]Rev, []Rev) {
+	missing := []Rev{}
+	maxGeneration := uint64(0)
+	for _, rev := range revs {
+		if t.Contains(rev) {
+			continue
+		}
+		missing = append(missing, rev)
+		if rev.Generation() > maxGeneration {
+			maxGeneration = rev.Generation()
+		}
+	}
+
+	possibleAncestors := []Rev{}
+	for _, leaf := range t.Leaves() {
+		if leaf.Rev.Generation() < maxGeneration {
+			possibleAncestors = append(possibleAncestors, leaf.Rev)
+		}
+	}
+
+	return missing, possibleAncestors
+}
@@ -0,0 +1,27 @@
+package couchdb
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+// RevsDiff returns the provided revisions per document id, that are missing in the database, together with
+// the known revisions, that might be their ancestors. Documents without missing revisions are omitted.
+func (db *Database) RevsDiff(ctx context.Context, revisions map[string][]string) (map[string]value.RevsDiff, error) {
+	r := map[string]value.RevsDiff{}
+	if err := db.requestJSON(ctx, http.MethodPost, db.path("_revs_diff"), nil, revisions, &r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// MissingRevs returns the provided revisions per document id, that are missing in the database.
+func (db *Database) MissingRevs(ctx context.Context, revisions map[string][]string) (value.MissingRevs, error) {
+	r := value.MissingRevs{}
+	if err := db.requestJSON(ctx, http.MethodPost, db.path("_missing_revs"), nil, revisions, &r); err != nil {
+		return r, err
+	}
+	return r, nil
+}
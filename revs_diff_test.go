@@ -0,0 +1,56 @@
+package couchdb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+	"github.com/simia-tech/couchdb/value"
+)
+
+func TestRevsDiff(t *testing.T) {
+	e := setUpTestEnvironment(t)
+	defer e.tearDown()
+
+	db := couchdb.NewDatabase(e.client, "test")
+	require.NoError(t, db.Create(e.ctx))
+	defer db.Delete(e.ctx)
+
+	document := couchdb.NewDocument(db, "test", "")
+	require.NoError(t, document.Store(e.ctx, map[string]interface{}{"test": "value"}))
+
+	t.Run("RevsDiff", func(t *testing.T) {
+		diff, err := db.RevsDiff(e.ctx, map[string][]string{
+			"test":    {document.Revision(), "2-abc"},
+			"missing": {"1-abc"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]value.RevsDiff{
+			"test":    {Missing: []string{"2-abc"}, PossibleAncestors: []string{document.Revision()}},
+			"missing": {Missing: []string{"1-abc"}},
+		}, diff)
+	})
+
+	t.Run("RevsDiffWithoutMissing", func(t *testing.T) {
+		diff, err := db.RevsDiff(e.ctx, map[string][]string{"test": {document.Revision()}})
+		require.NoError(t, err)
+
+		assert.Empty(t, diff)
+	})
+
+	t.Run("MissingRevs", func(t *testing.T) {
+		result, err := db.MissingRevs(e.ctx, map[string][]string{
+			"test":    {document.Revision(), "2-abc"},
+			"missing": {"1-abc"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string][]string{
+			"test":    {"2-abc"},
+			"missing": {"1-abc"},
+		}, result.MissingRevs)
+	})
+}
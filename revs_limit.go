@@ -0,0 +1,20 @@
+package couchdb
+
+import (
+	"context"
+	"net/http"
+)
+
+// RevsLimit fetches the maximum number of revisions, that are tracked per document.
+func (db *Database) RevsLimit(ctx context.Context) (uint, error) {
+	r := uint(0)
+	if err := db.requestJSON(ctx, http.MethodGet, db.path("_revs_limit"), nil, nil, &r); err != nil {
+		return 0, err
+	}
+	return r, nil
+}
+
+// SetRevsLimit sets the maximum number of revisions, that are tracked per document.
+func (db *Database) SetRevsLimit(ctx context.Context, limit uint) error {
+	return db.requestJSON(ctx, http.MethodPut, db.path("_revs_limit"), nil, limit, nil)
+}
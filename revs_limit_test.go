@@ -0,0 +1,90 @@
+package couchdb_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+)
+
+func TestRevsLimit(t *testing.T) {
+	e := setUpTestEnvironment(t)
+	defer e.tearDown()
+
+	db := couchdb.NewDatabase(e.client, "test")
+	require.NoError(t, db.Create(e.ctx, couchdb.WithRevsLimit(100)))
+	defer db.Delete(e.ctx)
+
+	t.Run("Create", func(t *testing.T) {
+		limit, err := db.RevsLimit(e.ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint(100), limit)
+	})
+
+	t.Run("Set", func(t *testing.T) {
+		require.NoError(t, db.SetRevsLimit(e.ctx, 50))
+
+		limit, err := db.RevsLimit(e.ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint(50), limit)
+	})
+
+	t.Run("Ensure", func(t *testing.T) {
+		require.NoError(t, db.Ensure(e.ctx, couchdb.WithRevsLimit(10)))
+
+		limit, err := db.RevsLimit(e.ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint(10), limit)
+	})
+}
+
+func TestEnsureRevsLimitStub(t *testing.T) {
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/test", func(request stubRequest) stubResponse {
+		if request.method == http.MethodHead {
+			return stubResponse{statusCode: 200}
+		}
+		return stubResponse{statusCode: 200, body: `{"db_name":"test"}`}
+	})
+	httpClient.handle("http://a/test/_revs_limit", func(request stubRequest) stubResponse {
+		if request.method == http.MethodPut {
+			return stubResponse{statusCode: 200, body: `{"ok":true}`}
+		}
+		return stubResponse{statusCode: 200, body: `1000`}
+	})
+
+	require.NoError(t, couchdb.NewDatabase(client, "test").Ensure(context.Background(), couchdb.WithRevsLimit(10)))
+
+	last := httpClient.requests[len(httpClient.requests)-1]
+	assert.Equal(t, http.MethodPut, last.method)
+	assert.Equal(t, "http://a/test/_revs_limit", last.url)
+	assert.JSONEq(t, `10`, last.body)
+}
+
+func TestEnsureRevsLimitOfCreatedDatabaseStub(t *testing.T) {
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/test", func(request stubRequest) stubResponse {
+		switch request.method {
+		case http.MethodHead:
+			return stubResponse{statusCode: 404}
+		case http.MethodPut:
+			return stubResponse{statusCode: 201, body: `{"ok":true}`}
+		}
+		return stubResponse{statusCode: 200, body: `{"db_name":"test"}`}
+	})
+	httpClient.handle("http://a/test/_revs_limit", jsonResponse(200, `{"ok":true}`))
+
+	require.NoError(t, couchdb.NewDatabase(client, "test").Ensure(context.Background(), couchdb.WithRevsLimit(10)))
+
+	revsLimitRequests := []string{}
+	for _, request := range httpClient.requests {
+		if request.url == "http://a/test/_revs_limit" {
+			revsLimitRequests = append(revsLimitRequests, request.method)
+		}
+	}
+	assert.Equal(t, []string{http.MethodPut}, revsLimitRequests)
+}
@@ -0,0 +1,198 @@
+package couchdb
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+const schedulerPageSize = 1000
+
+// SchedulerJobs fetches the replication jobs, that are currently handled by the replication scheduler. The
+// result can be paged using the `WithLimit` and `WithSkip` options.
+func (c *Client) SchedulerJobs(ctx context.Context, options ...ViewOption) (value.SchedulerJobs, error) {
+	r := value.SchedulerJobs{}
+
+	query, err := viewQuery(options)
+	if err != nil {
+		return r, err
+	}
+
+	if err := c.requestJSON(ctx, http.MethodGet, withQuery("/_scheduler/jobs", query), nil, nil, &r); err != nil {
+		return r, err
+	}
+
+	return r, nil
+}
+
+// SchedulerDocs fetches the states of the replications, that are defined in replicator databases. The
+// result can be paged using the `WithLimit` and `WithSkip` options.
+func (c *Client) SchedulerDocs(ctx context.Context, options ...ViewOption) (value.SchedulerDocs, error) {
+	r := value.SchedulerDocs{}
+
+	query, err := viewQuery(options)
+	if err != nil {
+		return r, err
+	}
+
+	if err := c.requestJSON(ctx, http.MethodGet, withQuery("/_scheduler/docs", query), nil, nil, &r); err != nil {
+		return r, err
+	}
+
+	return r, nil
+}
+
+// WatchReplications polls the scheduler docs and jobs in the provided interval and calls the provided
+// function for every replication that changed its state. Replications of replicator databases are taken from
+// the docs, transient replications, that have been started via `_replicate`, from the jobs. On the first poll,
+// all replications are reported as new. The function blocks until the context is cancelled or a request fails.
+func (c *Client) WatchReplications(
+	ctx context.Context,
+	interval time.Duration,
+	fn func(value.ReplicationTransition),
+) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	states := map[string]value.SchedulerDoc{}
+	for {
+		docs, err := c.replicationStates(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		states = reportTransitions(states, docs, fn)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// replicationStates fetches all pages of the scheduler docs and jobs. The transient jobs are returned as docs
+// without database and doc id, whose state is derived from the job's latest event.
+func (c *Client) replicationStates(ctx context.Context) ([]value.SchedulerDoc, error) {
+	docs := []value.SchedulerDoc{}
+	for skip := uint(0); ; skip += schedulerPageSize {
+		page, err := c.SchedulerDocs(ctx, WithLimit(schedulerPageSize), WithSkip(skip))
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, page.Docs...)
+		if len(page.Docs) < schedulerPageSize {
+			break
+		}
+	}
+
+	for skip := uint(0); ; skip += schedulerPageSize {
+		page, err := c.SchedulerJobs(ctx, WithLimit(schedulerPageSize), WithSkip(skip))
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range page.Jobs {
+			if job.DocID == "" {
+				docs = append(docs, transientJobState(job))
+			}
+		}
+		if len(page.Jobs) < schedulerPageSize {
+			break
+		}
+	}
+
+	return docs, nil
+}
+
+// transientJobState returns the state of the provided transient job. The job's history starts with the
+// latest event.
+func transientJobState(job value.SchedulerJob) value.SchedulerDoc {
+	doc := value.SchedulerDoc{
+		ID:        job.ID,
+		Node:      job.Node,
+		Source:    job.Source,
+		Target:    job.Target,
+		State:     value.ReplicationStatePending,
+		StartTime: job.StartTime,
+		Info:      job.Info,
+	}
+	for _, event := range job.History {
+		if event.Type == value.SchedulerJobEventCrashed {
+			doc.ErrorCount++
+		}
+	}
+	if len(job.History) > 0 {
+		latest := job.History[0]
+		doc.LastUpdated = latest.Timestamp
+		switch latest.Type {
+		case value.SchedulerJobEventStarted:
+			doc.State = value.ReplicationStateRunning
+		case value.SchedulerJobEventCrashed:
+			doc.State = value.ReplicationStateCrashing
+			if doc.Info == nil {
+				doc.Info = &value.SchedulerInfo{Error: latest.Reason}
+			}
+		}
+	}
+	return doc
+}
+
+// reportTransitions compares the previous with the current states, reports the differences and returns the
+// current states.
+func reportTransitions(
+	previous map[string]value.SchedulerDoc,
+	docs []value.SchedulerDoc,
+	fn func(value.ReplicationTransition),
+) map[string]value.SchedulerDoc {
+	current := make(map[string]value.SchedulerDoc, len(docs))
+	for _, doc := range docs {
+		key := transitionKey(doc)
+		current[key] = doc
+
+		from := previous[key].State
+		if from == doc.State {
+			continue
+		}
+		transition := value.ReplicationTransition{
+			Database:   doc.Database,
+			DocID:      doc.DocID,
+			ID:         doc.ID,
+			From:       from,
+			To:         doc.State,
+			ErrorCount: doc.ErrorCount,
+			Time:       doc.LastUpdated,
+		}
+		if doc.Info != nil {
+			transition.Error = doc.Info.Error
+		}
+		fn(transition)
+	}
+
+	for key, doc := range previous {
+		if _, ok := current[key]; ok {
+			continue
+		}
+		fn(value.ReplicationTransition{
+			Database: doc.Database,
+			DocID:    doc.DocID,
+			ID:       doc.ID,
+			From:     doc.State,
+			Time:     time.Now().UTC(),
+		})
+	}
+
+	return current
+}
+
+// transitionKey returns the key of the provided replication. Replications of replicator databases are
+// identified by their document, transient replications by their job id.
+func transitionKey(doc value.SchedulerDoc) string {
+	if doc.DocID == "" {
+		return doc.ID
+	}
+	return doc.Database + "/" + doc.DocID
+}
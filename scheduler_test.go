@@ -0,0 +1,149 @@
+package couchdb_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+	"github.com/simia-tech/couchdb/value"
+)
+
+func TestScheduler(t *testing.T) {
+	e := setUpTestEnvironment(t)
+	defer e.tearDown()
+
+	t.Run("Jobs", func(t *testing.T) {
+		jobs, err := e.client.SchedulerJobs(e.ctx, couchdb.WithLimit(10))
+		require.NoError(t, err)
+
+		assert.LessOrEqual(t, len(jobs.Jobs), 10)
+	})
+
+	t.Run("Docs", func(t *testing.T) {
+		docs, err := e.client.SchedulerDocs(e.ctx, couchdb.WithLimit(10))
+		require.NoError(t, err)
+
+		assert.LessOrEqual(t, len(docs.Docs), 10)
+	})
+}
+
+func TestWatchReplications(t *testing.T) {
+	responses := []string{
+		`{"docs":[{"database":"_replicator","doc_id":"a","state":"running"},{"database":"_replicator","doc_id":"b","state":"pending"}]}`,
+		`{"docs":[{"database":"_replicator","doc_id":"a","state":"running"},{"database":"_replicator","doc_id":"b","state":"crashing","error_count":1,"info":{"error":"unauthorized"}}]}`,
+		`{"docs":[{"database":"_replicator","doc_id":"b","state":"crashing","error_count":2,"info":{"error":"unauthorized"}}]}`,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mutex := sync.Mutex{}
+	calls := 0
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/_scheduler/docs", func(stubRequest) stubResponse {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if calls == len(responses)-1 {
+			cancel()
+		}
+		response := responses[calls]
+		if calls < len(responses)-1 {
+			calls++
+		}
+		return stubResponse{statusCode: 200, body: response}
+	})
+	httpClient.handle("http://a/_scheduler/jobs", jsonResponse(200, `{"jobs":[]}`))
+
+	transitions := []value.ReplicationTransition{}
+	require.NoError(t, client.WatchReplications(ctx, time.Millisecond, func(transition value.ReplicationTransition) {
+		transition.Time = time.Time{}
+		transitions = append(transitions, transition)
+	}))
+
+	assert.Equal(t, []value.ReplicationTransition{
+		{Database: "_replicator", DocID: "a", To: value.ReplicationStateRunning},
+		{Database: "_replicator", DocID: "b", To: value.ReplicationStatePending},
+		{Database: "_replicator", DocID: "b", From: value.ReplicationStatePending, To: value.ReplicationStateCrashing, ErrorCount: 1, Error: "unauthorized"},
+		{Database: "_replicator", DocID: "a", From: value.ReplicationStateRunning},
+	}, transitions)
+}
+
+func TestWatchReplicationsOfTransientJobs(t *testing.T) {
+	responses := []string{
+		`{"jobs":[{"id":"j1","history":[{"type":"started"},{"type":"added"}]},{"id":"j2","database":"_replicator","doc_id":"b","history":[{"type":"started"}]}]}`,
+		`{"jobs":[{"id":"j1","history":[{"type":"crashed","reason":"unauthorized"},{"type":"started"},{"type":"added"}]}]}`,
+		`{"jobs":[]}`,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mutex := sync.Mutex{}
+	calls := 0
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/_scheduler/docs", jsonResponse(200, `{"docs":[]}`))
+	httpClient.handle("http://a/_scheduler/jobs", func(stubRequest) stubResponse {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if calls == len(responses)-1 {
+			cancel()
+		}
+		response := responses[calls]
+		if calls < len(responses)-1 {
+			calls++
+		}
+		return stubResponse{statusCode: 200, body: response}
+	})
+
+	transitions := []value.ReplicationTransition{}
+	require.NoError(t, client.WatchReplications(ctx, time.Millisecond, func(transition value.ReplicationTransition) {
+		transition.Time = time.Time{}
+		transitions = append(transitions, transition)
+	}))
+
+	assert.Equal(t, []value.ReplicationTransition{
+		{ID: "j1", To: value.ReplicationStateRunning},
+		{ID: "j1", From: value.ReplicationStateRunning, To: value.ReplicationStateCrashing, ErrorCount: 1, Error: "unauthorized"},
+		{ID: "j1", From: value.ReplicationStateCrashing},
+	}, transitions)
+}
+
+func TestWatchReplicationsPaging(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/_scheduler/docs", func(request stubRequest) stubResponse {
+		if strings.Contains(request.url, "skip=0") {
+			docs := []string{}
+			for index := 0; index < 1000; index++ {
+				docs = append(docs, fmt.Sprintf(`{"database":"_replicator","doc_id":"%d","state":"running"}`, index))
+			}
+			return stubResponse{statusCode: 200, body: `{"docs":[` + strings.Join(docs, ",") + `]}`}
+		}
+		return stubResponse{statusCode: 200, body: `{"docs":[{"database":"_replicator","doc_id":"last","state":"running"}]}`}
+	})
+	httpClient.handle("http://a/_scheduler/jobs", func(stubRequest) stubResponse {
+		cancel()
+		return stubResponse{statusCode: 200, body: `{"jobs":[]}`}
+	})
+
+	transitions := 0
+	require.NoError(t, client.WatchReplications(ctx, time.Millisecond, func(value.ReplicationTransition) {
+		transitions++
+	}))
+
+	assert.Equal(t, 1001, transitions)
+	assert.Equal(t, []string{
+		"http://a/_scheduler/docs?limit=1000&skip=0",
+		"http://a/_scheduler/docs?limit=1000&skip=1000",
+		"http://a/_scheduler/jobs?limit=1000&skip=0",
+	}, httpClient.urls())
+}
@@ -0,0 +1,22 @@
+package couchdb
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+// Security fetches the security object of the database.
+func (db *Database) Security(ctx context.Context) (value.Security, error) {
+	r := value.Security{}
+	if err := db.requestJSON(ctx, http.MethodGet, db.path("_security"), nil, nil, &r); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// SetSecurity replaces the security object of the database.
+func (db *Database) SetSecurity(ctx context.Context, security value.Security) error {
+	return db.requestJSON(ctx, http.MethodPut, db.path("_security"), nil, security, nil)
+}
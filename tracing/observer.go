@@ -0,0 +1,108 @@
+// Package tracing provides a couchdb.Observer that creates a span for each request. The `Tracer`
+// and `Span` interfaces are kept minimal, so they can be adapted to any tracing library without
+// adding a dependency to this module.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/simia-tech/couchdb"
+)
+
+// Tracer defines an interface to start spans.
+type Tracer interface {
+	Start(context.Context, string) (context.Context, Span)
+}
+
+// Span defines an interface of a span.
+type Span interface {
+	SetAttributes(...Attribute)
+	RecordError(error)
+	End()
+}
+
+// Attribute holds a key value pair that is attached to a span.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Various standard attribute keys.
+const (
+	KeyDBSystem          = "db.system"
+	KeyDBName            = "db.name"
+	KeyDBOperation       = "db.operation"
+	KeyHTTPMethod        = "http.request.method"
+	KeyHTTPRoute         = "http.route"
+	KeyHTTPStatusCode    = "http.response.status_code"
+	KeyHTTPRequestSize   = "http.request.body.size"
+	KeyHTTPResponseSize  = "http.response.body.size"
+	KeyServerAddress     = "server.address"
+	KeyURLFull           = "url.full"
+	ValueDBSystemCouchDB = "couchdb"
+)
+
+// Observer implements a `couchdb.Observer` that traces the performed requests.
+type Observer struct {
+	tracer Tracer
+}
+
+var _ couchdb.Observer = &Observer{}
+
+type spanKey struct{}
+
+// NewObserver returns a new observer that starts the spans using the provided tracer.
+func NewObserver(tracer Tracer) *Observer {
+	return &Observer{tracer: tracer}
+}
+
+// RequestStarted implements `couchdb.Observer`.
+func (o *Observer) RequestStarted(ctx context.Context, ri couchdb.RequestInfo) context.Context {
+	ctx, span := o.tracer.Start(ctx, "couchdb "+ri.Method+" "+ri.PathTemplate)
+
+	attributes := []Attribute{
+		{Key: KeyDBSystem, Value: ValueDBSystemCouchDB},
+		{Key: KeyDBOperation, Value: ri.Method + " " + ri.PathTemplate},
+		{Key: KeyHTTPMethod, Value: ri.Method},
+		{Key: KeyHTTPRoute, Value: ri.PathTemplate},
+	}
+	if ri.Database != "" {
+		attributes = append(attributes, Attribute{Key: KeyDBName, Value: ri.Database})
+	}
+	span.SetAttributes(attributes...)
+
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// RequestFinished implements `couchdb.Observer`.
+func (o *Observer) RequestFinished(ctx context.Context, ri couchdb.RequestInfo, response couchdb.ResponseInfo) {
+	span, ok := ctx.Value(spanKey{}).(Span)
+	if !ok {
+		return
+	}
+
+	attributes := []Attribute{
+		{Key: KeyHTTPRequestSize, Value: response.BytesSent},
+		{Key: KeyHTTPResponseSize, Value: response.BytesReceived},
+	}
+	if response.Endpoint != "" {
+		attributes = append(attributes, Attribute{Key: KeyURLFull, Value: response.Endpoint + ri.Path})
+		if u, err := url.Parse(response.Endpoint); err == nil {
+			attributes = append(attributes, Attribute{Key: KeyServerAddress, Value: u.Hostname()})
+		}
+	}
+	if response.Err == nil {
+		attributes = append(attributes, Attribute{Key: KeyHTTPStatusCode, Value: response.StatusCode})
+	}
+	span.SetAttributes(attributes...)
+
+	if response.Err != nil {
+		span.RecordError(response.Err)
+	} else if response.StatusCode >= http.StatusInternalServerError {
+		span.RecordError(fmt.Errorf("status %d", response.StatusCode))
+	}
+	span.End()
+}
@@ -0,0 +1,173 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+	"github.com/simia-tech/couchdb/tracing"
+)
+
+var errUnreachable = errors.New("unreachable")
+
+type fakeSpan struct {
+	name       string
+	attributes map[string]interface{}
+	errs       []error
+	ended      bool
+}
+
+func (s *fakeSpan) SetAttributes(attributes ...tracing.Attribute) {
+	for _, a := range attributes {
+		s.attributes[a.Key] = a.Value
+	}
+}
+
+func (s *fakeSpan) RecordError(err error) {
+	s.errs = append(s.errs, err)
+}
+
+func (s *fakeSpan) End() {
+	s.ended = true
+}
+
+type fakeTracer struct {
+	mutex sync.Mutex
+	spans []*fakeSpan
+}
+
+func (t *fakeTracer) Start(ctx context.Context, name string) (context.Context, tracing.Span) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	span := &fakeSpan{name: name, attributes: map[string]interface{}{}}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+func (t *fakeTracer) last() *fakeSpan {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.spans[len(t.spans)-1]
+}
+
+type fakeHTTPClient struct {
+	statusCode int
+	body       string
+	err        error
+}
+
+func (c *fakeHTTPClient) Request(
+	_ context.Context,
+	_, _ string,
+	_ http.Header,
+	body io.Reader,
+) (int, http.Header, io.ReadCloser, error) {
+	if body != nil {
+		if _, err := io.Copy(ioutil.Discard, body); err != nil {
+			return 0, nil, nil, err
+		}
+	}
+	if c.err != nil {
+		return 0, nil, nil, c.err
+	}
+	return c.statusCode, http.Header{}, ioutil.NopCloser(bytes.NewBufferString(c.body)), nil
+}
+
+func TestObserver(t *testing.T) {
+	newClient := func(t *testing.T, httpClient couchdb.HTTPClient) (*couchdb.Client, *fakeTracer) {
+		tracer := &fakeTracer{}
+		client, err := couchdb.NewClient("http://a",
+			couchdb.WithHTTPClient(httpClient),
+			couchdb.WithObserver(tracing.NewObserver(tracer)))
+		require.NoError(t, err)
+		t.Cleanup(func() { client.Close() })
+		return client, tracer
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		body := `{"ok":true,"id":"abc","rev":"1-a"}`
+		client, tracer := newClient(t, &fakeHTTPClient{statusCode: 201, body: body})
+
+		document := couchdb.NewDocument(couchdb.NewDatabase(client, "test"), "abc", "")
+		require.NoError(t, document.Store(context.Background(), map[string]interface{}{"test": "value"}))
+
+		span := tracer.last()
+		assert.Equal(t, "couchdb PUT /{db}/{docid}", span.name)
+		assert.Equal(t, tracing.ValueDBSystemCouchDB, span.attributes[tracing.KeyDBSystem])
+		assert.Equal(t, "test", span.attributes[tracing.KeyDBName])
+		assert.Equal(t, "PUT", span.attributes[tracing.KeyHTTPMethod])
+		assert.Equal(t, "/{db}/{docid}", span.attributes[tracing.KeyHTTPRoute])
+		assert.Equal(t, "PUT /{db}/{docid}", span.attributes[tracing.KeyDBOperation])
+		assert.Equal(t, 201, span.attributes[tracing.KeyHTTPStatusCode])
+		assert.Equal(t, int64(len(`{"test":"value"}`+"\n")), span.attributes[tracing.KeyHTTPRequestSize])
+		assert.Equal(t, int64(len(body)), span.attributes[tracing.KeyHTTPResponseSize])
+		assert.Equal(t, "a", span.attributes[tracing.KeyServerAddress])
+		assert.Equal(t, "http://a/test/abc", span.attributes[tracing.KeyURLFull])
+		assert.Empty(t, span.errs)
+		assert.True(t, span.ended)
+	})
+
+	t.Run("EndAfterClose", func(t *testing.T) {
+		client, tracer := newClient(t, &fakeHTTPClient{statusCode: 200, body: `{"_id":"abc","_rev":"1-a"}`})
+
+		rc := io.ReadCloser(nil)
+		require.NoError(t, couchdb.NewDocument(couchdb.NewDatabase(client, "test"), "abc", "").Fetch(context.Background(), &rc))
+
+		span := tracer.last()
+		assert.False(t, span.ended)
+
+		_, err := ioutil.ReadAll(rc)
+		require.NoError(t, err)
+		assert.False(t, span.ended)
+
+		require.NoError(t, rc.Close())
+		assert.True(t, span.ended)
+		assert.Equal(t, int64(len(`{"_id":"abc","_rev":"1-a"}`)), span.attributes[tracing.KeyHTTPResponseSize])
+	})
+
+	t.Run("TransportError", func(t *testing.T) {
+		client, tracer := newClient(t, &fakeHTTPClient{err: errUnreachable})
+
+		_, err := client.AllDatabases(context.Background())
+		require.Error(t, err)
+
+		span := tracer.last()
+		require.Len(t, span.errs, 1)
+		assert.ErrorIs(t, span.errs[0], errUnreachable)
+		assert.NotContains(t, span.attributes, tracing.KeyHTTPStatusCode)
+		assert.True(t, span.ended)
+	})
+
+	t.Run("ServerError", func(t *testing.T) {
+		client, tracer := newClient(t, &fakeHTTPClient{statusCode: 500, body: `{"error":"unknown_error"}`})
+
+		_, err := client.AllDatabases(context.Background())
+		require.Error(t, err)
+
+		span := tracer.last()
+		assert.Equal(t, 500, span.attributes[tracing.KeyHTTPStatusCode])
+		assert.Len(t, span.errs, 1)
+		assert.True(t, span.ended)
+	})
+
+	t.Run("ClientError", func(t *testing.T) {
+		client, tracer := newClient(t, &fakeHTTPClient{statusCode: 404, body: `{"error":"not_found"}`})
+
+		_, err := client.AllDatabases(context.Background())
+		require.Error(t, err)
+
+		span := tracer.last()
+		assert.Equal(t, 404, span.attributes[tracing.KeyHTTPStatusCode])
+		assert.Empty(t, span.errs)
+		assert.True(t, span.ended)
+	})
+}
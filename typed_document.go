@@ -0,0 +1,121 @@
+package couchdb
+
+import (
+	"context"
+	"reflect"
+)
+
+// TypedDocument implements a document that is backed by a struct value. The meta fields `_id`, `_rev`,
+// `_deleted`, `_attachments` and `_conflicts` are read from and written to the struct fields with the
+// corresponding json names or an embedded `Meta` struct. The tags of these fields should contain the
+// `omitempty` flag.
+type TypedDocument[T any] struct {
+	document *Document
+	value    *T
+	fields   metaFields
+	err      error
+}
+
+// NewTypedDocument returns a new document for the provided value. The id and revision are taken
+// from the value.
+func NewTypedDocument[T any](database *Database, value *T) *TypedDocument[T] {
+	fields, err := metaFieldsOf(reflect.TypeOf(value).Elem())
+	td := &TypedDocument[T]{
+		document: NewDocument(database, "", ""),
+		value:    value,
+		fields:   fields,
+		err:      err,
+	}
+	if err == nil {
+		td.document.id = td.fields.string(td.reflectValue(), metaID)
+		td.document.revision = td.fields.string(td.reflectValue(), metaRevision)
+	}
+	return td
+}
+
+// Value returns the document's value.
+func (td *TypedDocument[T]) Value() *T {
+	return td.value
+}
+
+// ID returns the document's id.
+func (td *TypedDocument[T]) ID() string {
+	return td.document.ID()
+}
+
+// Revision returns the document's revision.
+func (td *TypedDocument[T]) Revision() string {
+	return td.document.Revision()
+}
+
+// Store saves the value to the database. If the id is empty, it will be save at a generated id. The
+// id and the new revision are written back to the value.
+func (td *TypedDocument[T]) Store(ctx context.Context) error {
+	return td.store(ctx, td.value)
+}
+
+// store saves the provided data, which represents the encoded value.
+func (td *TypedDocument[T]) store(ctx context.Context, data interface{}) error {
+	if err := td.sync(); err != nil {
+		return err
+	}
+	if err := td.document.Store(ctx, data); err != nil {
+		return err
+	}
+	td.fields.setString(td.reflectValue(), metaID, td.document.id)
+	td.fields.setString(td.reflectValue(), metaRevision, td.document.revision)
+	return nil
+}
+
+// Fetch loads the value from the database. The value is only replaced, if the fetch succeeded.
+func (td *TypedDocument[T]) Fetch(ctx context.Context, options ...FetchOption) error {
+	if err := td.sync(); err != nil {
+		return err
+	}
+	fetched := new(T)
+	if err := td.document.Fetch(ctx, fetched, options...); err != nil {
+		return err
+	}
+	*td.value = *fetched
+	td.fields.setString(td.reflectValue(), metaID, td.document.id)
+	td.fields.setString(td.reflectValue(), metaRevision, td.document.revision)
+	return nil
+}
+
+// Delete deletes the document from the database. The revision of the deletion tombstone is written back
+// to the value and it's marked as deleted.
+func (td *TypedDocument[T]) Delete(ctx context.Context) error {
+	if err := td.sync(); err != nil {
+		return err
+	}
+	if err := td.document.Delete(ctx); err != nil {
+		return err
+	}
+	td.fields.setString(td.reflectValue(), metaRevision, td.document.revision)
+	td.fields.setBool(td.reflectValue(), metaDeleted, true)
+	return nil
+}
+
+// setID sets the id of the document and the value.
+func (td *TypedDocument[T]) setID(id string) {
+	td.document.id = id
+	td.fields.setString(td.reflectValue(), metaID, id)
+}
+
+// sync takes over id and revision from the value, if they have been set.
+func (td *TypedDocument[T]) sync() error {
+	if td.err != nil {
+		return td.err
+	}
+	if id := td.fields.string(td.reflectValue(), metaID); id != "" {
+		td.document.id = id
+	}
+	if revision := td.fields.string(td.reflectValue(), metaRevision); revision != "" {
+		td.document.revision = revision
+	}
+	return nil
+}
+
+func (td *TypedDocument[T]) reflectValue() reflect.Value {
+	return reflect.ValueOf(td.value).Elem()
+}
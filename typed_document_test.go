@@ -0,0 +1,152 @@
+package couchdb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/couchdb"
+)
+
+type testTaggedValue struct {
+	ID       string `json:"_id,omitempty"`
+	Revision string `json:"_rev,omitempty"`
+	Deleted  bool   `json:"_deleted,omitempty"`
+	Test     string `json:"test"`
+}
+
+type testEmbeddedValue struct {
+	couchdb.Meta
+	Test string `json:"test"`
+}
+
+func TestTypedDocument(t *testing.T) {
+	e := setUpTestEnvironment(t)
+	defer e.tearDown()
+
+	db := couchdb.NewDatabase(e.client, "test")
+	require.NoError(t, db.Create(e.ctx))
+	defer db.Delete(e.ctx)
+
+	t.Run("Store", func(t *testing.T) {
+		t.Run("Tagged", func(t *testing.T) {
+			value := &testTaggedValue{Test: "value"}
+			document := couchdb.NewTypedDocument(db, value)
+
+			require.NoError(t, document.Store(e.ctx))
+
+			assert.Regexp(t, `^[0-9a-f]+$`, value.ID)
+			assert.Regexp(t, `^1\-[0-9a-f]+$`, value.Revision)
+
+			value.Test = "another value"
+			require.NoError(t, document.Store(e.ctx))
+
+			assert.Regexp(t, `^2\-[0-9a-f]+$`, value.Revision)
+			assert.Equal(t, value.Revision, document.Revision())
+		})
+
+		t.Run("Embedded", func(t *testing.T) {
+			value := &testEmbeddedValue{Meta: couchdb.Meta{ID: "embedded"}, Test: "value"}
+			document := couchdb.NewTypedDocument(db, value)
+
+			require.NoError(t, document.Store(e.ctx))
+
+			assert.Equal(t, "embedded", value.ID)
+			assert.Regexp(t, `^1\-[0-9a-f]+$`, value.Revision)
+		})
+
+		t.Run("Conflict", func(t *testing.T) {
+			value := &testTaggedValue{ID: "conflict", Test: "value"}
+			require.NoError(t, couchdb.NewTypedDocument(db, value).Store(e.ctx))
+
+			otherValue := &testTaggedValue{ID: "conflict", Test: "another value"}
+			err := couchdb.NewTypedDocument(db, otherValue).Store(e.ctx)
+			assert.ErrorIs(t, err, couchdb.ErrConflict)
+		})
+	})
+
+	t.Run("Fetch", func(t *testing.T) {
+		value := &testEmbeddedValue{Test: "value"}
+		require.NoError(t, couchdb.NewTypedDocument(db, value).Store(e.ctx))
+
+		fetchedValue := &testEmbeddedValue{Meta: couchdb.Meta{ID: value.ID}}
+		require.NoError(t, couchdb.NewTypedDocument(db, fetchedValue).Fetch(e.ctx))
+
+		assert.Equal(t, value, fetchedValue)
+	})
+
+	t.Run("FetchMissing", func(t *testing.T) {
+		value := &testEmbeddedValue{Meta: couchdb.Meta{ID: "missing"}}
+		err := couchdb.NewTypedDocument(db, value).Fetch(e.ctx)
+		assert.ErrorIs(t, err, couchdb.ErrNotFound)
+		assert.Equal(t, "missing", value.ID)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		value := &testTaggedValue{Test: "value"}
+		document := couchdb.NewTypedDocument(db, value)
+		require.NoError(t, document.Store(e.ctx))
+
+		require.NoError(t, document.Delete(e.ctx))
+
+		assert.Regexp(t, `^2\-[0-9a-f]+$`, value.Revision)
+		assert.True(t, value.Deleted)
+
+		err := couchdb.NewTypedDocument(db, &testTaggedValue{ID: value.ID}).Fetch(e.ctx)
+		assert.ErrorIs(t, err, couchdb.ErrNotFound)
+	})
+
+	t.Run("UnsupportedType", func(t *testing.T) {
+		value := "value"
+		err := couchdb.NewTypedDocument(db, &value).Store(e.ctx)
+		assert.ErrorIs(t, err, couchdb.ErrUnsupportedType)
+	})
+}
+
+func TestTypedDocumentFetchKeepsValueOnError(t *testing.T) {
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/test/abc", jsonResponse(404, `{"error":"not_found","reason":"missing"}`))
+
+	value := &testTaggedValue{ID: "abc", Revision: "1-a", Test: "value"}
+	err := couchdb.NewTypedDocument(couchdb.NewDatabase(client, "test"), value).Fetch(context.Background())
+	assert.ErrorIs(t, err, couchdb.ErrNotFound)
+
+	assert.Equal(t, &testTaggedValue{ID: "abc", Revision: "1-a", Test: "value"}, value)
+}
+
+type testEmbeddedPointerValue struct {
+	*couchdb.Meta
+	Test string `json:"test"`
+}
+
+func TestTypedDocumentEmbeddedPointer(t *testing.T) {
+	client, httpClient := newStubClient(t)
+	httpClient.handle("http://a/test/abc", jsonResponse(201, `{"ok":true,"id":"abc","rev":"2-b"}`))
+
+	db := couchdb.NewDatabase(client, "test")
+
+	t.Run("Allocated", func(t *testing.T) {
+		value := &testEmbeddedPointerValue{Meta: &couchdb.Meta{ID: "abc", Revision: "1-a"}, Test: "value"}
+		document := couchdb.NewTypedDocument(db, value)
+		assert.Equal(t, "abc", document.ID())
+		assert.Equal(t, "1-a", document.Revision())
+
+		require.NoError(t, document.Store(context.Background()))
+		assert.Equal(t, "2-b", value.Revision)
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		httpClient.handle("http://a/test", jsonResponse(201, `{"ok":true,"id":"generated","rev":"1-c"}`))
+
+		value := &testEmbeddedPointerValue{Test: "value"}
+		document := couchdb.NewTypedDocument(db, value)
+		assert.Equal(t, "", document.ID())
+
+		require.NoError(t, document.Store(context.Background()))
+		require.NotNil(t, value.Meta)
+		assert.Equal(t, "generated", value.ID)
+		assert.Equal(t, "1-c", value.Revision)
+	})
+}
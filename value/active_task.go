@@ -0,0 +1,44 @@
+package value
+
+// ActiveTask holds a task, that is currently running on a node of the cluster. Depending on the type, only
+// some of the fields are set.
+type ActiveTask struct {
+	Type           ActiveTaskType `json:"type"`
+	Node           string         `json:"node"`
+	PID            string         `json:"pid"`
+	StartedOn      int64          `json:"started_on"`
+	UpdatedOn      int64          `json:"updated_on"`
+	Progress       uint           `json:"progress"`
+	ChangesDone    uint           `json:"changes_done"`
+	TotalChanges   uint           `json:"total_changes"`
+	Database       string         `json:"database,omitempty"`
+	DesignDocument string         `json:"design_document,omitempty"`
+	Index          string         `json:"index,omitempty"`
+	Phase          string         `json:"phase,omitempty"`
+
+	DocID                 string `json:"doc_id,omitempty"`
+	ReplicationID         string `json:"replication_id,omitempty"`
+	Source                string `json:"source,omitempty"`
+	Target                string `json:"target,omitempty"`
+	Continuous            bool   `json:"continuous,omitempty"`
+	SourceSeq             Seq    `json:"source_seq,omitempty"`
+	CheckpointedSourceSeq Seq    `json:"checkpointed_source_seq,omitempty"`
+	ChangesPending        uint   `json:"changes_pending,omitempty"`
+	RevisionsChecked      uint   `json:"revisions_checked,omitempty"`
+	MissingRevisionsFound uint   `json:"missing_revisions_found,omitempty"`
+	DocsRead              uint   `json:"docs_read,omitempty"`
+	DocsWritten           uint   `json:"docs_written,omitempty"`
+	DocWriteFailures      uint   `json:"doc_write_failures,omitempty"`
+}
+
+// ActiveTaskType defines the type of an active task.
+type ActiveTaskType string
+
+// Various active task types.
+const (
+	ActiveTaskIndexer            ActiveTaskType = "indexer"
+	ActiveTaskViewCompaction     ActiveTaskType = "view_compaction"
+	ActiveTaskDatabaseCompaction ActiveTaskType = "database_compaction"
+	ActiveTaskReplication        ActiveTaskType = "replication"
+	ActiveTaskSearchIndexer      ActiveTaskType = "search_indexer"
+)
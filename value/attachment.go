@@ -0,0 +1,15 @@
+package value
+
+// Attachment holds the infos of a document attachment as they appear in the `_attachments` field. The
+// data is only set if the attachment has been fetched inline.
+type Attachment struct {
+	ContentType   string `json:"content_type,omitempty"`
+	Digest        string `json:"digest,omitempty"`
+	Length        int64  `json:"length,omitempty"`
+	RevPos        uint   `json:"revpos,omitempty"`
+	Stub          bool   `json:"stub,omitempty"`
+	Follows       bool   `json:"follows,omitempty"`
+	Encoding      string `json:"encoding,omitempty"`
+	EncodedLength int64  `json:"encoded_length,omitempty"`
+	Data          []byte `json:"data,omitempty"`
+}
@@ -0,0 +1,10 @@
+package value
+
+// AttachmentInfo holds the infos about an attachment that are returned in the response header.
+type AttachmentInfo struct {
+	ContentType     string
+	ContentEncoding string
+	Length          int64
+	Digest          string
+	ContentRange    string
+}
@@ -0,0 +1,30 @@
+package value
+
+import "encoding/json"
+
+// BulkGetRequest holds a document revision to fetch using a bulk get request.
+type BulkGetRequest struct {
+	ID        string   `json:"id"`
+	Revision  string   `json:"rev,omitempty"`
+	AttsSince []string `json:"atts_since,omitempty"`
+}
+
+// BulkGetResult holds the fetched revisions of a document of a bulk get request.
+type BulkGetResult struct {
+	ID   string           `json:"id"`
+	Docs []BulkGetOutcome `json:"docs"`
+}
+
+// BulkGetOutcome holds either the fetched document revision or the error.
+type BulkGetOutcome struct {
+	OK    json.RawMessage `json:"ok,omitempty"`
+	Error *BulkGetError   `json:"error,omitempty"`
+}
+
+// BulkGetError holds the error of a fetched document revision.
+type BulkGetError struct {
+	ID       string `json:"id"`
+	Revision string `json:"rev"`
+	Error    string `json:"error"`
+	Reason   string `json:"reason"`
+}
@@ -0,0 +1,10 @@
+package value
+
+// BulkResult holds the result of a single document of a bulk request.
+type BulkResult struct {
+	OK       bool   `json:"ok"`
+	ID       string `json:"id"`
+	Revision string `json:"rev"`
+	Error    string `json:"error,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
@@ -0,0 +1,17 @@
+package value
+
+import "encoding/json"
+
+// Change holds an entry of a changes feed.
+type Change struct {
+	Seq     Seq             `json:"seq"`
+	ID      string          `json:"id"`
+	Changes []ChangeRev     `json:"changes"`
+	Deleted bool            `json:"deleted,omitempty"`
+	Doc     json.RawMessage `json:"doc,omitempty"`
+}
+
+// ChangeRev holds a changed revision of a changes feed entry.
+type ChangeRev struct {
+	Revision string `json:"rev"`
+}
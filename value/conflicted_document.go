@@ -0,0 +1,8 @@
+package value
+
+// ConflictedDocument holds the winning revision and the conflicting revisions of a document.
+type ConflictedDocument struct {
+	ID        string   `json:"_id"`
+	Revision  string   `json:"_rev"`
+	Conflicts []string `json:"_conflicts"`
+}
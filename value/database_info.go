@@ -2,12 +2,29 @@ package value
 
 // DatabaseInfo holds infos about the database.
 type DatabaseInfo struct {
-	Name                  string  `json:"db_name"`
-	PurgeSequence         string  `json:"purge_seq"`
-	UpdateSequence        string  `json:"update_seq"`
-	DocumentDeletionCount uint    `json:"doc_del_count"`
-	DocumentCount         uint    `json:"doc_count"`
-	DiskFormatVersion     uint    `json:"disk_format_version"`
-	CompactRunning        bool    `json:"compact_running"`
-	Cluster               Cluster `json:"cluster"`
+	Name                  string        `json:"db_name"`
+	PurgeSequence         Seq           `json:"purge_seq"`
+	UpdateSequence        Seq           `json:"update_seq"`
+	CompactedSequence     Seq           `json:"compacted_seq,omitempty"`
+	DocumentDeletionCount uint          `json:"doc_del_count"`
+	DocumentCount         uint          `json:"doc_count"`
+	DiskFormatVersion     uint          `json:"disk_format_version"`
+	CompactRunning        bool          `json:"compact_running"`
+	InstanceStartTime     string        `json:"instance_start_time"`
+	Sizes                 Sizes         `json:"sizes"`
+	Props                 DatabaseProps `json:"props"`
+	Cluster               Cluster       `json:"cluster"`
+}
+
+// DatabaseProps holds the properties of a database, that are set at creation.
+type DatabaseProps struct {
+	Partitioned bool `json:"partitioned,omitempty"`
+}
+
+// DatabaseInfoResult holds the infos about a database, that has been requested via `_dbs_info`. If the
+// database doesn't exist, only the error is set.
+type DatabaseInfoResult struct {
+	Key   string        `json:"key"`
+	Info  *DatabaseInfo `json:"info,omitempty"`
+	Error string        `json:"error,omitempty"`
 }
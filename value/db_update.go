@@ -0,0 +1,18 @@
+package value
+
+// DBUpdate holds an entry of the database updates feed.
+type DBUpdate struct {
+	DBName string       `json:"db_name"`
+	Type   DBUpdateType `json:"type"`
+	Seq    Seq          `json:"seq"`
+}
+
+// DBUpdateType defines the type of a database update.
+type DBUpdateType string
+
+// Various database update types.
+const (
+	DBUpdateCreated DBUpdateType = "created"
+	DBUpdateUpdated DBUpdateType = "updated"
+	DBUpdateDeleted DBUpdateType = "deleted"
+)
@@ -0,0 +1,54 @@
+package value
+
+import "encoding/json"
+
+// FindQuery holds the parameters of a mango query.
+type FindQuery struct {
+	Selector       interface{}   `json:"selector"`
+	Fields         []string      `json:"fields,omitempty"`
+	Sort           []interface{} `json:"sort,omitempty"`
+	Limit          uint          `json:"limit,omitempty"`
+	Skip           uint          `json:"skip,omitempty"`
+	UseIndex       interface{}   `json:"use_index,omitempty"`
+	Conflicts      bool          `json:"conflicts,omitempty"`
+	Bookmark       string        `json:"bookmark,omitempty"`
+	ExecutionStats bool          `json:"execution_stats,omitempty"`
+}
+
+// FindResult holds the result of a mango query.
+type FindResult struct {
+	Docs           []json.RawMessage `json:"docs"`
+	Bookmark       string            `json:"bookmark"`
+	Warning        string            `json:"warning,omitempty"`
+	ExecutionStats *ExecutionStats   `json:"execution_stats,omitempty"`
+}
+
+// ExecutionStats holds the execution statistics of a mango query.
+type ExecutionStats struct {
+	TotalKeysExamined       uint    `json:"total_keys_examined"`
+	TotalDocsExamined       uint    `json:"total_docs_examined"`
+	TotalQuorumDocsExamined uint    `json:"total_quorum_docs_examined"`
+	ResultsReturned         uint    `json:"results_returned"`
+	ExecutionTimeMs         float64 `json:"execution_time_ms"`
+}
+
+// ExplainResult holds the index and the parameters, that would be used to perform a mango query.
+type ExplainResult struct {
+	DBName   string          `json:"dbname"`
+	Index    ExplainIndex    `json:"index"`
+	Selector json.RawMessage `json:"selector"`
+	Options  json.RawMessage `json:"opts"`
+	Limit    uint            `json:"limit"`
+	Skip     uint            `json:"skip"`
+	Fields   json.RawMessage `json:"fields"`
+	Range    json.RawMessage `json:"range,omitempty"`
+	MRArgs   json.RawMessage `json:"mrargs,omitempty"`
+}
+
+// ExplainIndex holds the index, that would be used to perform a mango query.
+type ExplainIndex struct {
+	DesignDocument *string         `json:"ddoc"`
+	Name           string          `json:"name"`
+	Type           string          `json:"type"`
+	Definition     json.RawMessage `json:"def"`
+}
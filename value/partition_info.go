@@ -0,0 +1,17 @@
+package value
+
+// PartitionInfo holds infos about a partition of a partitioned database.
+type PartitionInfo struct {
+	DBName                string `json:"db_name"`
+	Partition             string `json:"partition"`
+	DocumentCount         uint   `json:"doc_count"`
+	DocumentDeletionCount uint   `json:"doc_del_count"`
+	Sizes                 Sizes  `json:"sizes"`
+}
+
+// Sizes holds the sizes of a database or partition in bytes.
+type Sizes struct {
+	Active   uint64 `json:"active"`
+	External uint64 `json:"external"`
+	File     uint64 `json:"file,omitempty"`
+}
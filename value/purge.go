@@ -0,0 +1,7 @@
+package value
+
+// PurgeResult holds the result of a purge request.
+type PurgeResult struct {
+	PurgeSeq Seq                 `json:"purge_seq"`
+	Purged   map[string][]string `json:"purged"`
+}
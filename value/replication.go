@@ -0,0 +1,115 @@
+package value
+
+// ReplicationLog holds the checkpoint of a replication, that is stored as local document on the source and
+// the target database.
+type ReplicationLog struct {
+	Revision             string               `json:"_rev,omitempty"`
+	SessionID            string               `json:"session_id"`
+	SourceLastSeq        Seq                  `json:"source_last_seq"`
+	ReplicationIDVersion int                  `json:"replication_id_version"`
+	History              []ReplicationHistory `json:"history"`
+}
+
+// ReplicationHistory holds the statistics of a replication session.
+type ReplicationHistory struct {
+	SessionID        string `json:"session_id"`
+	StartTime        string `json:"start_time"`
+	EndTime          string `json:"end_time"`
+	StartLastSeq     Seq    `json:"start_last_seq"`
+	EndLastSeq       Seq    `json:"end_last_seq"`
+	RecordedSeq      Seq    `json:"recorded_seq"`
+	MissingChecked   uint   `json:"missing_checked"`
+	MissingFound     uint   `json:"missing_found"`
+	DocsRead         uint   `json:"docs_read"`
+	DocsWritten      uint   `json:"docs_written"`
+	DocWriteFailures uint   `json:"doc_write_failures"`
+}
+
+// ReplicationProgress holds the progress of a replication that is performed by the client.
+type ReplicationProgress struct {
+	ReplicationID    string
+	SessionID        string
+	SourceLastSeq    Seq
+	MissingChecked   uint
+	MissingFound     uint
+	DocsRead         uint
+	DocsWritten      uint
+	DocWriteFailures uint
+}
+
+// ReplicationEndpoint holds the url of a replication source or target together with the headers, that
+// are sent to it, e.g. to authorize the replication.
+type ReplicationEndpoint struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// ReplicationSpec holds the parameters of a replication, that is performed by the server.
+type ReplicationSpec struct {
+	Source       ReplicationEndpoint    `json:"source"`
+	Target       ReplicationEndpoint    `json:"target"`
+	Continuous   bool                   `json:"continuous,omitempty"`
+	CreateTarget bool                   `json:"create_target,omitempty"`
+	Filter       string                 `json:"filter,omitempty"`
+	QueryParams  map[string]interface{} `json:"query_params,omitempty"`
+	Selector     interface{}            `json:"selector,omitempty"`
+	DocIDs       []string               `json:"doc_ids,omitempty"`
+	SinceSeq     Seq                    `json:"since_seq,omitempty"`
+}
+
+// ReplicationRequest holds the body of a transient replication request to `_replicate`.
+type ReplicationRequest struct {
+	ReplicationSpec
+	ReplicationID string `json:"replication_id,omitempty"`
+	Cancel        bool   `json:"cancel,omitempty"`
+}
+
+// ReplicationResult holds the response of a transient replication request. Continuous and cancelled
+// replications only report the local id of their checkpoint.
+type ReplicationResult struct {
+	OK                   bool                 `json:"ok"`
+	LocalID              string               `json:"_local_id,omitempty"`
+	SessionID            string               `json:"session_id,omitempty"`
+	SourceLastSeq        Seq                  `json:"source_last_seq,omitempty"`
+	ReplicationIDVersion int                  `json:"replication_id_version,omitempty"`
+	History              []ReplicationHistory `json:"history,omitempty"`
+	NoChanges            bool                 `json:"no_changes,omitempty"`
+}
+
+// ReplicationDocument holds a persistent replication, that is stored in the `_replicator` database. The
+// state fields are maintained by the server.
+type ReplicationDocument struct {
+	ID       string `json:"_id,omitempty"`
+	Revision string `json:"_rev,omitempty"`
+	ReplicationSpec
+	ReplicationState       ReplicationState  `json:"_replication_state,omitempty"`
+	ReplicationStateTime   string            `json:"_replication_state_time,omitempty"`
+	ReplicationStateReason string            `json:"_replication_state_reason,omitempty"`
+	ReplicationID          string            `json:"_replication_id,omitempty"`
+	ReplicationStats       *ReplicationStats `json:"_replication_stats,omitempty"`
+}
+
+// ReplicationState defines the state of a replication.
+type ReplicationState string
+
+// Various replication states.
+const (
+	ReplicationStateInitializing ReplicationState = "initializing"
+	ReplicationStateRunning      ReplicationState = "running"
+	ReplicationStatePending      ReplicationState = "pending"
+	ReplicationStateCrashing     ReplicationState = "crashing"
+	ReplicationStateError        ReplicationState = "error"
+	ReplicationStateFailed       ReplicationState = "failed"
+	ReplicationStateCompleted    ReplicationState = "completed"
+)
+
+// ReplicationStats holds the statistics of a replication, that is performed by the server.
+type ReplicationStats struct {
+	RevisionsChecked      uint `json:"revisions_checked"`
+	MissingRevisionsFound uint `json:"missing_revisions_found"`
+	DocsRead              uint `json:"docs_read"`
+	DocsWritten           uint `json:"docs_written"`
+	DocWriteFailures      uint `json:"doc_write_failures"`
+	ChangesPending        uint `json:"changes_pending"`
+	CheckpointedSourceSeq Seq  `json:"checkpointed_source_seq,omitempty"`
+}
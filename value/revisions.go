@@ -0,0 +1,21 @@
+package value
+
+// Revisions holds the revision history of a document as it's returned in the `_revisions` field. The ids
+// are ordered from the newest to the oldest revision, starting at generation start.
+type Revisions struct {
+	Start uint64   `json:"start"`
+	IDs   []string `json:"ids"`
+}
+
+// RevInfo holds the availability of a revision as it's returned in the `_revs_info` field.
+type RevInfo struct {
+	Revision string `json:"rev"`
+	Status   string `json:"status"`
+}
+
+// Various revision status.
+const (
+	RevStatusAvailable = "available"
+	RevStatusMissing   = "missing"
+	RevStatusDeleted   = "deleted"
+)
@@ -0,0 +1,12 @@
+package value
+
+// RevsDiff holds the missing revisions of a document and the known revisions that might be their ancestors.
+type RevsDiff struct {
+	Missing           []string `json:"missing"`
+	PossibleAncestors []string `json:"possible_ancestors,omitempty"`
+}
+
+// MissingRevs holds the missing revisions per document id.
+type MissingRevs struct {
+	MissingRevs map[string][]string `json:"missing_revs"`
+}
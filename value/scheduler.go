@@ -0,0 +1,85 @@
+package value
+
+import "time"
+
+// SchedulerJobs holds the replication jobs, that are currently handled by the replication scheduler.
+type SchedulerJobs struct {
+	TotalRows uint           `json:"total_rows"`
+	Offset    uint           `json:"offset"`
+	Jobs      []SchedulerJob `json:"jobs"`
+}
+
+// SchedulerJob holds a replication job of the replication scheduler.
+type SchedulerJob struct {
+	ID        string              `json:"id"`
+	Database  string              `json:"database"`
+	DocID     string              `json:"doc_id"`
+	Node      string              `json:"node"`
+	PID       string              `json:"pid"`
+	Source    string              `json:"source"`
+	Target    string              `json:"target"`
+	User      string              `json:"user"`
+	StartTime time.Time           `json:"start_time"`
+	History   []SchedulerJobEvent `json:"history"`
+	Info      *SchedulerInfo      `json:"info"`
+}
+
+// SchedulerJobEvent holds an event of a replication job's history.
+type SchedulerJobEvent struct {
+	Timestamp time.Time             `json:"timestamp"`
+	Type      SchedulerJobEventType `json:"type"`
+	Reason    string                `json:"reason,omitempty"`
+}
+
+// SchedulerJobEventType defines the type of a replication job event.
+type SchedulerJobEventType string
+
+// Various replication job event types.
+const (
+	SchedulerJobEventAdded   SchedulerJobEventType = "added"
+	SchedulerJobEventStarted SchedulerJobEventType = "started"
+	SchedulerJobEventCrashed SchedulerJobEventType = "crashed"
+	SchedulerJobEventStopped SchedulerJobEventType = "stopped"
+)
+
+// SchedulerDocs holds the states of the replications, that are defined in replicator databases.
+type SchedulerDocs struct {
+	TotalRows uint           `json:"total_rows"`
+	Offset    uint           `json:"offset"`
+	Docs      []SchedulerDoc `json:"docs"`
+}
+
+// SchedulerDoc holds the state of a replication, that is defined in a replicator database.
+type SchedulerDoc struct {
+	ID          string           `json:"id"`
+	Database    string           `json:"database"`
+	DocID       string           `json:"doc_id"`
+	Node        string           `json:"node"`
+	Source      string           `json:"source"`
+	Target      string           `json:"target"`
+	State       ReplicationState `json:"state"`
+	ErrorCount  uint             `json:"error_count"`
+	StartTime   time.Time        `json:"start_time"`
+	LastUpdated time.Time        `json:"last_updated"`
+	Info        *SchedulerInfo   `json:"info"`
+}
+
+// SchedulerInfo holds the statistics of a replication or the error that caused it to crash or fail.
+type SchedulerInfo struct {
+	ReplicationStats
+	Error string `json:"error,omitempty"`
+}
+
+// ReplicationTransition holds a state change of a replication. Replications, that are defined in a replicator
+// database, are identified by the database and doc id, transient replications only by their id. A new
+// replication has no previous state and a removed replication has no current state.
+type ReplicationTransition struct {
+	Database   string
+	DocID      string
+	ID         string
+	From       ReplicationState
+	To         ReplicationState
+	ErrorCount uint
+	Error      string
+	Time       time.Time
+}
@@ -0,0 +1,13 @@
+package value
+
+// Security holds the security object of a database.
+type Security struct {
+	Admins  SecurityGroup `json:"admins"`
+	Members SecurityGroup `json:"members"`
+}
+
+// SecurityGroup holds the users and roles, that belong to a group of the security object.
+type SecurityGroup struct {
+	Names []string `json:"names"`
+	Roles []string `json:"roles"`
+}
@@ -0,0 +1,48 @@
+package value
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Seq holds an opaque update sequence. Depending on the couchdb version, sequences are encoded as
+// strings or numbers. Both representations are accepted. When encoding, sequences that consist only of
+// digits are written as numbers and all others as strings, so a string sequence like "42" becomes 42.
+type Seq string
+
+// MarshalJSON implements `json.Marshaler`.
+func (s Seq) MarshalJSON() ([]byte, error) {
+	if _, err := strconv.ParseUint(string(s), 10, 64); err == nil {
+		return []byte(s), nil
+	}
+	return json.Marshal(string(s))
+}
+
+// UnmarshalJSON implements `json.Unmarshaler`.
+func (s *Seq) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*s = ""
+	case len(data) > 0 && data[0] == '"':
+		value := ""
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		*s = Seq(value)
+	default:
+		number := json.Number("")
+		if err := json.Unmarshal(data, &number); err != nil {
+			return fmt.Errorf("sequence %s: %w", data, err)
+		}
+		*s = Seq(number)
+	}
+	return nil
+}
+
+// String returns the sequence as it's used in query parameters.
+func (s Seq) String() string {
+	return string(s)
+}
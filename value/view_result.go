@@ -0,0 +1,19 @@
+package value
+
+import "encoding/json"
+
+// ViewResult holds the result of a view query.
+type ViewResult struct {
+	TotalRows uint      `json:"total_rows"`
+	Offset    uint      `json:"offset"`
+	Rows      []ViewRow `json:"rows"`
+}
+
+// ViewRow holds a row of a view result.
+type ViewRow struct {
+	ID    string          `json:"id,omitempty"`
+	Key   json.RawMessage `json:"key"`
+	Value json.RawMessage `json:"value"`
+	Doc   json.RawMessage `json:"doc,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
@@ -0,0 +1,152 @@
+package couchdb
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/simia-tech/couchdb/value"
+)
+
+// ViewOption defines a function that can modify the query parameters of a view request.
+type ViewOption func(url.Values) error
+
+// WithKey returns an option that only returns rows with the provided key.
+func WithKey(key interface{}) ViewOption {
+	return func(q url.Values) error {
+		return setJSONQuery(q, "key", key)
+	}
+}
+
+// WithStartKey returns an option that returns rows starting at the provided key.
+func WithStartKey(key interface{}) ViewOption {
+	return func(q url.Values) error {
+		return setJSONQuery(q, "start_key", key)
+	}
+}
+
+// WithEndKey returns an option that returns rows up to the provided key.
+func WithEndKey(key interface{}) ViewOption {
+	return func(q url.Values) error {
+		return setJSONQuery(q, "end_key", key)
+	}
+}
+
+// WithStartKeyDocID returns an option that returns rows starting at the provided document id.
+func WithStartKeyDocID(id string) ViewOption {
+	return func(q url.Values) error {
+		q.Set("start_key_doc_id", id)
+		return nil
+	}
+}
+
+// WithEndKeyDocID returns an option that returns rows up to the provided document id.
+func WithEndKeyDocID(id string) ViewOption {
+	return func(q url.Values) error {
+		q.Set("end_key_doc_id", id)
+		return nil
+	}
+}
+
+// WithInclusiveEnd returns an option that sets whether the end key is included.
+func WithInclusiveEnd(value bool) ViewOption {
+	return func(q url.Values) error {
+		q.Set("inclusive_end", strconv.FormatBool(value))
+		return nil
+	}
+}
+
+// WithLimit returns an option that limits the number of returned rows.
+func WithLimit(value uint) ViewOption {
+	return func(q url.Values) error {
+		q.Set("limit", strconv.FormatUint(uint64(value), 10))
+		return nil
+	}
+}
+
+// WithSkip returns an option that skips the provided number of rows.
+func WithSkip(value uint) ViewOption {
+	return func(q url.Values) error {
+		q.Set("skip", strconv.FormatUint(uint64(value), 10))
+		return nil
+	}
+}
+
+// WithDescending returns an option that returns the rows in descending order.
+func WithDescending() ViewOption {
+	return func(q url.Values) error {
+		q.Set("descending", "true")
+		return nil
+	}
+}
+
+// WithIncludeDocs returns an option that includes the documents in the rows.
+func WithIncludeDocs() ViewOption {
+	return func(q url.Values) error {
+		q.Set("include_docs", "true")
+		return nil
+	}
+}
+
+// WithReduce returns an option that sets whether the reduce function is used.
+func WithReduce(value bool) ViewOption {
+	return func(q url.Values) error {
+		q.Set("reduce", strconv.FormatBool(value))
+		return nil
+	}
+}
+
+// WithGroupLevel returns an option that groups the reduced rows by the provided number of key elements.
+func WithGroupLevel(value uint) ViewOption {
+	return func(q url.Values) error {
+		q.Set("group_level", strconv.FormatUint(uint64(value), 10))
+		return nil
+	}
+}
+
+// AllDocs fetches the rows of the `_all_docs` view.
+func (db *Database) AllDocs(ctx context.Context, options ...ViewOption) (value.ViewResult, error) {
+	return db.view(ctx, db.path("_all_docs"), options)
+}
+
+// LocalDocs fetches the rows of the `_local_docs` view, that lists the local documents of the database.
+func (db *Database) LocalDocs(ctx context.Context, options ...ViewOption) (value.ViewResult, error) {
+	return db.view(ctx, db.path("_local_docs"), options)
+}
+
+// View fetches the rows of the provided view in the provided design document. The design document
+// name is expected without the `_design/` prefix.
+func (db *Database) View(ctx context.Context, designDocument, view string, options ...ViewOption) (value.ViewResult, error) {
+	return db.view(ctx, db.path(viewSegments(designDocument, view)...), options)
+}
+
+func (db *Database) view(ctx context.Context, path string, options []ViewOption) (value.ViewResult, error) {
+	r := value.ViewResult{}
+
+	query, err := viewQuery(options)
+	if err != nil {
+		return r, err
+	}
+
+	if err := db.requestJSON(ctx, http.MethodGet, withQuery(path, query), nil, nil, &r); err != nil {
+		return r, err
+	}
+
+	return r, nil
+}
+
+// viewSegments returns the escaped path segments of the provided view.
+func viewSegments(designDocument, view string) []string {
+	return []string{"_design", escapePathSegment(designDocument), "_view", escapePathSegment(view)}
+}
+
+func viewQuery(options []ViewOption) (url.Values, error) {
+	query := url.Values{}
+	for _, o := range options {
+		if err := o(query); err != nil {
+			return nil, err
+		}
+	}
+	return query, nil
+}